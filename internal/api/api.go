@@ -3,12 +3,15 @@ package api
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/pprof"
+	"os"
 	"runtime"
+	"slices"
 	"sync"
 
 	"github.com/gorilla/mux"
@@ -78,6 +81,15 @@ func New(
 		}
 	}
 
+	if conf.ClientCAFile != "" {
+		if conf.CertFile == "" {
+			return nil, errors.New("client_ca_file requires cert_file and key_file to be specified")
+		}
+		if server.TLSConfig, err = clientAuthTLSConfig(conf); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := conf.BasicAuth.Validate(); err != nil {
 		return nil, err
 	}
@@ -201,6 +213,7 @@ func New(
 	t.RegisterEndpoint("/ping", "Ping me.", handlePing)
 	t.RegisterEndpoint("/version", "Returns the service version.", handleVersion)
 	t.RegisterEndpoint("/endpoints", "Returns this map of endpoints.", handleEndpoints)
+	t.registerLogLevelEndpoint()
 
 	// If we want to expose a stats endpoint we register the endpoints.
 	if wHandlerFunc := stats.HandlerFunc(); wHandlerFunc != nil {
@@ -232,12 +245,15 @@ func (t *Type) RegisterEndpoint(path, desc string, handlerFunc http.HandlerFunc)
 	defer t.handlersMut.Unlock()
 
 	if _, exists := t.handlers[path]; !exists {
-		wrapHandler := t.conf.BasicAuth.WrapHandler(func(w http.ResponseWriter, r *http.Request) {
+		wrapHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			t.handlersMut.RLock()
 			h := t.handlers[path]
 			t.handlersMut.RUnlock()
 			h(w, r)
 		})
+		if !slices.Contains(t.conf.AuthExempt, path) {
+			wrapHandler = t.conf.BasicAuth.WrapHandler(wrapHandler)
+		}
 
 		GetMuxRoute(t.mux, path).Handler(wrapHandler)
 		GetMuxRoute(t.mux, t.conf.RootPath+path).Handler(wrapHandler)
@@ -245,6 +261,29 @@ func (t *Type) RegisterEndpoint(path, desc string, handlerFunc http.HandlerFunc)
 	t.handlers[path] = handlerFunc
 }
 
+func clientAuthTLSConfig(conf Config) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cert_file and key_file: %w", err)
+	}
+
+	caBytes, err := os.ReadFile(conf.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client_ca_file: %w", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caBytes) {
+		return nil, errors.New("client_ca_file did not contain any valid PEM encoded certificates")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
 // ListenAndServe launches the API and blocks until the server closes or fails.
 func (t *Type) ListenAndServe() error {
 	if !t.conf.Enabled {
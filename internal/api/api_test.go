@@ -1,15 +1,32 @@
 package api_test
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/redpanda-data/benthos/v4/internal/api"
 	"github.com/redpanda-data/benthos/v4/internal/component/metrics"
+	"github.com/redpanda-data/benthos/v4/internal/filepath/ifs"
 	"github.com/redpanda-data/benthos/v4/internal/log"
 
 	_ "github.com/redpanda-data/benthos/v4/public/components/pure"
@@ -183,3 +200,249 @@ func TestAPIBasicAuth(t *testing.T) {
 		}(tc))
 	}
 }
+
+func TestAPIBasicAuthPingExempt(t *testing.T) {
+	conf := api.NewConfig()
+	conf.BasicAuth.Enabled = true
+	conf.BasicAuth.Username = "myuser"
+	conf.BasicAuth.PasswordHash = "K7gNU3sdo+OL0wNhqoVWhr3g6s1xYv72ol/pe/Unols="
+
+	s, err := api.New("", "", conf, nil, log.Noop(), metrics.Noop())
+	require.NoError(t, err)
+
+	handler := s.Handler()
+
+	for path, expectedCode := range map[string]int{
+		"/ping":         http.StatusOK,
+		"/benthos/ping": http.StatusOK,
+		"/version":      http.StatusUnauthorized,
+	} {
+		request, _ := http.NewRequest("GET", path, http.NoBody)
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, request)
+		assert.Equal(t, expectedCode, response.Code, path)
+	}
+}
+
+func TestAPIBasicAuthCustomExempt(t *testing.T) {
+	conf := api.NewConfig()
+	conf.BasicAuth.Enabled = true
+	conf.BasicAuth.Username = "myuser"
+	conf.BasicAuth.PasswordHash = "K7gNU3sdo+OL0wNhqoVWhr3g6s1xYv72ol/pe/Unols="
+	conf.AuthExempt = []string{"/version"}
+
+	s, err := api.New("", "", conf, nil, log.Noop(), metrics.Noop())
+	require.NoError(t, err)
+
+	handler := s.Handler()
+
+	for path, expectedCode := range map[string]int{
+		"/ping":            http.StatusUnauthorized,
+		"/version":         http.StatusOK,
+		"/benthos/version": http.StatusOK,
+	} {
+		request, _ := http.NewRequest("GET", path, http.NoBody)
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, request)
+		assert.Equal(t, expectedCode, response.Code, path)
+	}
+}
+
+func TestAPILogLevel(t *testing.T) {
+	var buf bytes.Buffer
+
+	lConf := log.NewConfig()
+	lConf.LogLevel = "INFO"
+	logger, err := log.New(&buf, ifs.OS(), lConf)
+	require.NoError(t, err)
+
+	conf := api.NewConfig()
+	conf.DebugEndpoints = true
+
+	s, err := api.New("", "", conf, nil, logger, metrics.Noop())
+	require.NoError(t, err)
+
+	handler := s.Handler()
+
+	doRequest := func(method, body string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(method, "/log/level", strings.NewReader(body))
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, request)
+		return response
+	}
+
+	res := doRequest("GET", "")
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.JSONEq(t, `{"level":"INFO"}`, res.Body.String())
+
+	res = doRequest("POST", `{"level":"debug","path":"root.input"}`)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.JSONEq(t, `{"level":"INFO","overrides":{"root.input":"DEBUG"}}`, res.Body.String())
+
+	res = doRequest("POST", `{"level":"WARN"}`)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.JSONEq(t, `{"level":"WARN","overrides":{"root.input":"DEBUG"}}`, res.Body.String())
+
+	buf.Reset()
+	logger.Info("hidden")
+	logger.WithFields(map[string]string{"path": "root.input"}).Debug("shown")
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "shown")
+
+	res = doRequest("POST", `{"level":"nope"}`)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+
+	res = doRequest("POST", `not json`)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
+
+func TestAPILogLevelReadOnly(t *testing.T) {
+	lConf := log.NewConfig()
+	lConf.LogLevel = "INFO"
+	logger, err := log.New(io.Discard, ifs.OS(), lConf)
+	require.NoError(t, err)
+
+	s, err := api.New("", "", api.NewConfig(), nil, logger, metrics.Noop())
+	require.NoError(t, err)
+
+	handler := s.Handler()
+
+	doRequest := func(method, body string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(method, "/log/level", strings.NewReader(body))
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, request)
+		return response
+	}
+
+	res := doRequest("POST", `{"level":"DEBUG"}`)
+	assert.Equal(t, http.StatusMethodNotAllowed, res.Code)
+
+	res = doRequest("GET", "")
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.JSONEq(t, `{"level":"INFO"}`, res.Body.String())
+}
+
+func createTestCert(t *testing.T, tmpl, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return cert, key, certPem, keyPem
+}
+
+func TestAPIClientCertAuth(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile := func(name string, content []byte) string {
+		p := filepath.Join(tmpDir, name)
+		require.NoError(t, os.WriteFile(p, content, 0o600))
+		return p
+	}
+
+	caCert, caKey, caPem, _ := createTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+
+	_, _, serverCertPem, serverKeyPem := createTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test server"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, caCert, caKey)
+
+	_, _, clientCertPem, clientKeyPem := createTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "test client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, caCert, caKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	conf := api.NewConfig()
+	conf.Address = addr
+	conf.CertFile = writeFile("server.pem", serverCertPem)
+	conf.KeyFile = writeFile("server.key", serverKeyPem)
+	conf.ClientCAFile = writeFile("ca.pem", caPem)
+
+	s, err := api.New("", "", conf, nil, log.Noop(), metrics.Noop())
+	require.NoError(t, err)
+
+	go func() {
+		_ = s.ListenAndServe()
+	}()
+	t.Cleanup(func() {
+		_ = s.Shutdown(context.Background())
+	})
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+
+	clientKeyPair, err := tls.X509KeyPair(clientCertPem, clientKeyPem)
+	require.NoError(t, err)
+
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:      rootCAs,
+					Certificates: certs,
+					MinVersion:   tls.VersionTLS12,
+				},
+			},
+		}
+	}
+
+	var res *http.Response
+	assert.Eventually(t, func() bool {
+		res, err = newClient(clientKeyPair).Get("https://" + addr + "/ping")
+		return err == nil
+	}, time.Second*5, time.Millisecond*50)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, "pong", string(body))
+
+	_, err = newClient().Get("https://" + addr + "/ping")
+	require.Error(t, err)
+}
+
+func TestAPIClientCertAuthRequiresCert(t *testing.T) {
+	conf := api.NewConfig()
+	conf.ClientCAFile = "/does/not/exist.pem"
+
+	_, err := api.New("", "", conf, nil, log.Noop(), metrics.Noop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "client_ca_file requires cert_file and key_file")
+}
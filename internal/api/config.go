@@ -12,8 +12,10 @@ const (
 	fieldDebugEndpoints = "debug_endpoints"
 	fieldCertFile       = "cert_file"
 	fieldKeyFile        = "key_file"
+	fieldClientCAFile   = "client_ca_file"
 	fieldCORS           = "cors"
 	fieldBasicAuth      = "basic_auth"
+	fieldAuthExempt     = "auth_exempt_paths"
 )
 
 // Config contains the configuration fields for the Benthos API.
@@ -24,8 +26,10 @@ type Config struct {
 	DebugEndpoints bool                       `json:"debug_endpoints" yaml:"debug_endpoints"`
 	CertFile       string                     `json:"cert_file" yaml:"cert_file"`
 	KeyFile        string                     `json:"key_file" yaml:"key_file"`
+	ClientCAFile   string                     `json:"client_ca_file" yaml:"client_ca_file"`
 	CORS           httpserver.CORSConfig      `json:"cors" yaml:"cors"`
 	BasicAuth      httpserver.BasicAuthConfig `json:"basic_auth" yaml:"basic_auth"`
+	AuthExempt     []string                   `json:"auth_exempt_paths" yaml:"auth_exempt_paths"`
 }
 
 // NewConfig creates an API configuration struct fully populated with default values.
@@ -37,8 +41,10 @@ func NewConfig() Config {
 		DebugEndpoints: false,
 		CertFile:       "",
 		KeyFile:        "",
+		ClientCAFile:   "",
 		CORS:           httpserver.NewServerCORSConfig(),
 		BasicAuth:      httpserver.NewBasicAuthConfig(),
+		AuthExempt:     []string{"/ping"},
 	}
 }
 
@@ -62,11 +68,21 @@ func FromParsed(pConf *docs.ParsedConfig) (conf Config, err error) {
 	if conf.KeyFile, err = pConf.FieldString(fieldKeyFile); err != nil {
 		return
 	}
+	if pConf.Contains(fieldClientCAFile) {
+		if conf.ClientCAFile, err = pConf.FieldString(fieldClientCAFile); err != nil {
+			return
+		}
+	}
 	if conf.CORS, err = httpserver.CORSConfigFromParsed(pConf); err != nil {
 		return
 	}
 	if conf.BasicAuth, err = httpserver.BasicAuthConfigFromParsed(pConf); err != nil {
 		return
 	}
+	if pConf.Contains(fieldAuthExempt) {
+		if conf.AuthExempt, err = pConf.FieldStringList(fieldAuthExempt); err != nil {
+			return
+		}
+	}
 	return
 }
@@ -14,12 +14,14 @@ func Spec() docs.FieldSpecs {
 			fieldRootPath, "Specifies a general prefix for all endpoints, this can help isolate the service endpoints when using a reverse proxy with other shared services. All endpoints will still be registered at the root as well as behind the prefix, e.g. with a root_path set to `/foo` the endpoint `/version` will be accessible from both `/version` and `/foo/version`.",
 		).HasDefault("/benthos"),
 		docs.FieldBool(
-			fieldDebugEndpoints, "Whether to register a few extra endpoints that can be useful for debugging performance or behavioral problems.",
+			fieldDebugEndpoints, "Whether to register a few extra endpoints that can be useful for debugging performance or behavioral problems, and to allow changing the log level at runtime with POST requests to the `/log/level` endpoint.",
 		).HasDefault(false),
 		docs.FieldString(fieldCertFile, "An optional certificate file for enabling TLS.").Advanced().HasDefault(""),
 		docs.FieldString(fieldKeyFile, "An optional key file for enabling TLS.").Advanced().HasDefault(""),
+		docs.FieldString(fieldClientCAFile, "An optional file containing one or more PEM encoded certificate authorities. When set, TLS clients are required to present a certificate signed by one of these authorities. Requires `cert_file` and `key_file` to be set.").Advanced().HasDefault("").AtVersion("4.39.0"),
 		httpserver.ServerCORSFieldSpec(),
 		httpserver.BasicAuthFieldSpec(),
+		docs.FieldString(fieldAuthExempt, "A list of endpoint paths that are exempt from authentication, such as those used by liveness probes. Paths are matched exactly as they are registered, and are also exempt when prefixed with the `root_path`.").Array().Advanced().HasDefault([]any{"/ping"}).AtVersion("4.39.0"),
 	}
 }
 
@@ -87,6 +87,11 @@ type Dynamic struct {
 	// start times.
 	ids    map[string]time.Time
 	idsMut sync.Mutex
+
+	// pending is a set of dynamic components that are currently being updated
+	// or removed.
+	pending    map[string]struct{}
+	pendingMut sync.Mutex
 }
 
 // NewDynamic creates a new Dynamic API type.
@@ -97,6 +102,7 @@ func NewDynamic() *Dynamic {
 		configs:      map[string][]byte{},
 		configHashes: newDynamicConfMgr(),
 		ids:          map[string]time.Time{},
+		pending:      map[string]struct{}{},
 	}
 }
 
@@ -140,6 +146,25 @@ func (d *Dynamic) Started(id string, config []byte) {
 	}
 }
 
+// setPending marks a component as being updated or removed, returning false if
+// it is already pending.
+func (d *Dynamic) setPending(id string) bool {
+	d.pendingMut.Lock()
+	defer d.pendingMut.Unlock()
+
+	if _, exists := d.pending[id]; exists {
+		return false
+	}
+	d.pending[id] = struct{}{}
+	return true
+}
+
+func (d *Dynamic) unsetPending(id string) {
+	d.pendingMut.Lock()
+	delete(d.pending, id)
+	d.pendingMut.Unlock()
+}
+
 //------------------------------------------------------------------------------
 
 // HandleList is an http.HandleFunc for returning maps of active dynamic
@@ -298,6 +323,14 @@ func (d *Dynamic) HandleCRUD(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.Method == "POST" || r.Method == "DELETE" {
+		if !d.setPending(id) {
+			http.Error(w, fmt.Sprintf("Dynamic component '%v' is currently being updated", id), http.StatusConflict)
+			return
+		}
+		defer d.unsetPending(id)
+	}
+
 	switch r.Method {
 	case "POST":
 		httpErr = d.handlePOSTInput(w, r)
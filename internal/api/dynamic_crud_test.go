@@ -118,6 +118,51 @@ func TestDynamicDelete(t *testing.T) {
 	}
 }
 
+func TestDynamicConflictWhilePending(t *testing.T) {
+	dAPI := NewDynamic()
+	r := router(dAPI)
+
+	updateStarted := make(chan struct{})
+	updateRelease := make(chan struct{})
+	dAPI.OnUpdate(func(ctx context.Context, id string, content []byte) error {
+		close(updateStarted)
+		<-updateRelease
+		return nil
+	})
+	dAPI.OnDelete(func(ctx context.Context, id string) error {
+		t.Error("Unexpected delete called")
+		return nil
+	})
+
+	firstDone := make(chan int)
+	go func() {
+		request, _ := http.NewRequest("POST", "/input/foo", bytes.NewReader([]byte("hello world")))
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, request)
+		firstDone <- response.Code
+	}()
+	<-updateStarted
+
+	request, _ := http.NewRequest("DELETE", "/input/foo", http.NoBody)
+	response := httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	if exp, act := http.StatusConflict, response.Code; exp != act {
+		t.Errorf("Unexpected response code: %v != %v", act, exp)
+	}
+
+	request, _ = http.NewRequest("GET", "/input/bar", http.NoBody)
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	if exp, act := http.StatusNotFound, response.Code; exp != act {
+		t.Errorf("Unexpected response code: %v != %v", act, exp)
+	}
+
+	close(updateRelease)
+	if exp, act := http.StatusOK, <-firstDone; exp != act {
+		t.Errorf("Unexpected response code: %v != %v", act, exp)
+	}
+}
+
 func TestDynamicBasicCRUD(t *testing.T) {
 	dAPI := NewDynamic()
 	r := router(dAPI)
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/redpanda-data/benthos/v4/internal/log"
+)
+
+type logLevelBody struct {
+	Level     string            `json:"level"`
+	Path      string            `json:"path,omitempty"`
+	Overrides map[string]string `json:"overrides,omitempty"`
+}
+
+// registerLogLevelEndpoint registers an endpoint for reading the log level at
+// runtime when the logger supports it. Changing the log level is only allowed
+// when debug endpoints are enabled.
+func (t *Type) registerLogLevelEndpoint() {
+	ls, ok := t.log.(log.LevelSetter)
+	if !ok {
+		return
+	}
+
+	desc := "GET: Returns the current log level along with any component path overrides."
+	if t.conf.DebugEndpoints {
+		desc += " POST: Sets the log level from a JSON body of the form {\"level\":\"DEBUG\",\"path\":\"root.input\"}, where the optional path limits the level to the component at that path and any components beneath it, and an empty level removes the override of a path."
+	}
+
+	t.RegisterEndpoint(
+		"/log/level", desc,
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+			case http.MethodPost:
+				if !t.conf.DebugEndpoints {
+					http.Error(w, "Method not supported", http.StatusMethodNotAllowed)
+					return
+				}
+				reqBytes, err := io.ReadAll(r.Body)
+				if err != nil {
+					http.Error(w, fmt.Sprintf("Failed to read request body: %v", err), http.StatusBadRequest)
+					return
+				}
+				var body logLevelBody
+				if err := json.Unmarshal(reqBytes, &body); err != nil {
+					http.Error(w, fmt.Sprintf("Failed to parse request body: %v", err), http.StatusBadRequest)
+					return
+				}
+				if err := ls.SetLevel(body.Level, body.Path); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				switch {
+				case body.Path == "":
+					t.log.Info("Log level set to %v", body.Level)
+				case body.Level == "":
+					t.log.Info("Log level override of %v removed", body.Path)
+				default:
+					t.log.Info("Log level of %v set to %v", body.Path, body.Level)
+				}
+			default:
+				http.Error(w, "Method not supported", http.StatusMethodNotAllowed)
+				return
+			}
+
+			level, overrides := ls.Levels()
+			resBytes, err := json.Marshal(logLevelBody{
+				Level:     level,
+				Overrides: overrides,
+			})
+			if err != nil {
+				http.Error(w, "Internal server error", http.StatusBadGateway)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(resBytes)
+		},
+	)
+}
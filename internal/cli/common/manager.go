@@ -147,6 +147,10 @@ func RunManagerUntilStopped(
 
 	// Defer clean up.
 	defer func() {
+		if _, err := notifySystemd("STOPPING=1"); err != nil {
+			stopMgr.Manager().Logger().Error("Failed to notify systemd of shutdown: %v", err)
+		}
+
 		if exitDelay > 0 {
 			stopMgr.Manager().Logger().Info("Shutdown delay is in effect for %s\n", exitDelay)
 			if err := DelayShutdown(c.Context, exitDelay); err != nil {
@@ -167,9 +171,13 @@ func RunManagerUntilStopped(
 		ctx, done := context.WithTimeout(c.Context, exitTimeout)
 		defer done()
 
+		stopMgr.Manager().Logger().Info("Waiting up to %v for the pipeline to drain and close", exitTimeout)
+		stopStarted := time.Now()
 		if err := stopStrm.Stop(ctx); err != nil {
+			stopMgr.Manager().Logger().Warn("Pipeline failed to drain and close within %v: %v", exitTimeout, err)
 			return
 		}
+		stopMgr.Manager().Logger().Info("Pipeline closed after %v, closing resources", time.Since(stopStarted).Round(time.Millisecond))
 
 		if err := stopMgr.Stop(ctx); err != nil {
 			stopMgr.Manager().Logger().Warn(
@@ -0,0 +1,13 @@
+//go:build !wasm
+
+package common
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func notifyReloadSignal(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGHUP)
+}
@@ -0,0 +1,10 @@
+//go:build wasm
+
+package common
+
+import (
+	"os"
+)
+
+// notifyReloadSignal does nothing in WASM builds as there is no reload signal.
+func notifyReloadSignal(c chan<- os.Signal) {}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -101,6 +102,12 @@ func RunService(c *cli.Context, cliOpts *CLIOpts, streamsMode bool) error {
 	if err := cliOpts.OnStreamInit(stoppableStream); err != nil {
 		return err
 	}
+
+	lifecycleCtx, stopLifecycle := context.WithCancel(c.Context)
+	defer stopLifecycle()
+	go reloadOnSignal(lifecycleCtx, confReader, stoppableManager.Manager(), strict)
+	go notifySystemdUntilStopped(lifecycleCtx, stoppableManager.Manager().Logger(), stoppableStream)
+
 	return RunManagerUntilStopped(c, cliOpts, conf, stoppableManager, stoppableStream, dataStreamClosedChan)
 }
 
@@ -128,6 +135,26 @@ func DelayShutdown(ctx context.Context, duration time.Duration) error {
 	return nil
 }
 
+// reloadOnSignal blocks until the provided context is cancelled, re-reading
+// all config files each time the process receives a SIGHUP.
+func reloadOnSignal(ctx context.Context, confReader *config.Reader, mgr *manager.Type, strict bool) {
+	sigChan := make(chan os.Signal, 1)
+	notifyReloadSignal(sigChan)
+	defer signal.Stop(sigChan)
+
+	for {
+		select {
+		case <-sigChan:
+			mgr.Logger().Info("Received SIGHUP, reloading config files")
+			if err := confReader.TriggerReload(mgr, strict); err != nil {
+				mgr.Logger().Error("Failed to reload config files: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func initStreamsMode(
 	opts *CLIOpts,
 	strict, watching, enableAPI bool,
@@ -199,14 +226,25 @@ func initNormalMode(
 
 	stoppedChan = make(chan struct{})
 	var closeOnce sync.Once
+
+	// Tracks whether the current stream is being stopped in order to be
+	// replaced, in which case the closure of the stream must not be treated as
+	// the service terminating.
+	var currentReplaced *atomic.Bool
 	streamInit := func() (RunningStream, error) {
-		return stream.New(conf.Config, mgr, stream.OptOnClose(func() {
-			if !watching {
+		replaced := &atomic.Bool{}
+		strm, err := stream.New(conf.Config, mgr, stream.OptOnClose(func() {
+			if !watching && !replaced.Load() {
 				closeOnce.Do(func() {
 					close(stoppedChan)
 				})
 			}
 		}))
+		if err != nil {
+			return nil, err
+		}
+		currentReplaced = replaced
+		return strm, nil
 	}
 
 	initStream, err := streamInit()
@@ -222,10 +260,38 @@ func initNormalMode(
 		ctx, done := context.WithTimeout(context.Background(), 30*time.Second)
 		defer done()
 		// NOTE: We're ignoring observability field changes for now.
-		return stoppableStream.Replace(ctx, func() (RunningStream, error) {
+		currentReplaced.Store(true)
+
+		var initErr error
+		if err := stoppableStream.Replace(ctx, func() (RunningStream, error) {
+			prevConf := conf.Config
 			conf.Config = newStreamConf.Config
-			return streamInit()
-		})
+			strm, err := streamInit()
+			if err == nil {
+				return strm, nil
+			}
+			initErr = err
+
+			// Fall back to the previous config so that the service isn't left
+			// without a running stream.
+			conf.Config = prevConf
+			if strm, err = streamInit(); err != nil {
+				if !watching {
+					closeOnce.Do(func() {
+						close(stoppedChan)
+					})
+				}
+				return nil, err
+			}
+			logger.Warn("Failed to init updated stream, the previous config has been restored: %v", initErr)
+			return strm, nil
+		}); err != nil {
+			return err
+		}
+		if initErr != nil {
+			return fmt.Errorf("failed to init updated stream: %w", initErr)
+		}
+		return nil
 	}); err != nil {
 		return nil, nil, fmt.Errorf("failed to create config file watcher: %w", err)
 	}
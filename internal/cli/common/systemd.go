@@ -0,0 +1,95 @@
+package common
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/redpanda-data/benthos/v4/internal/log"
+)
+
+// notifySystemd sends a state message to the service manager using the
+// systemd notify protocol. Returns false without an error when the process
+// was not started with a notify socket.
+func notifySystemd(state string) (bool, error) {
+	socketPath := os.Getenv("NOTIFY_SOCKET")
+	if socketPath == "" {
+		return false, nil
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{
+		Name: socketPath,
+		Net:  "unixgram",
+	})
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	if _, err = conn.Write([]byte(state)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// systemdWatchdogInterval returns the interval at which watchdog keep-alive
+// messages should be sent to the service manager, or zero if the watchdog is
+// not enabled for this process.
+func systemdWatchdogInterval() time.Duration {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+	if pidStr := os.Getenv("WATCHDOG_PID"); pidStr != "" {
+		if pid, err := strconv.Atoi(pidStr); err != nil || pid != os.Getpid() {
+			return 0
+		}
+	}
+	// Keep-alive messages are sent at half the configured timeout in order to
+	// account for scheduling delays.
+	return time.Duration(usec) * time.Microsecond / 2
+}
+
+// notifySystemdUntilStopped blocks until the provided context is cancelled,
+// notifying a systemd service manager (when one is present) that the service
+// is ready once all connections of the stream are active, and sending watchdog
+// keep-alive messages when the watchdog is enabled.
+func notifySystemdUntilStopped(ctx context.Context, logger log.Modular, strm RunningStream) {
+	if os.Getenv("NOTIFY_SOCKET") == "" {
+		return
+	}
+
+	readyTicker := time.NewTicker(time.Millisecond * 500)
+	defer readyTicker.Stop()
+
+	var watchdogChan <-chan time.Time
+	if interval := systemdWatchdogInterval(); interval > 0 {
+		watchdogTicker := time.NewTicker(interval)
+		defer watchdogTicker.Stop()
+		watchdogChan = watchdogTicker.C
+	}
+
+	ready := false
+	for {
+		select {
+		case <-readyTicker.C:
+			if ready || !strm.ConnectionStatus().AllActive() {
+				continue
+			}
+			if _, err := notifySystemd("READY=1"); err != nil {
+				logger.Error("Failed to notify systemd of readiness: %v", err)
+				continue
+			}
+			ready = true
+			readyTicker.Stop()
+		case <-watchdogChan:
+			if _, err := notifySystemd("WATCHDOG=1"); err != nil {
+				logger.Error("Failed to send systemd watchdog keep-alive: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifySystemd(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+
+	sent, err := notifySystemd("READY=1")
+	require.NoError(t, err)
+	assert.False(t, sent)
+
+	socketPath := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	t.Setenv("NOTIFY_SOCKET", socketPath)
+
+	sent, err = notifySystemd("READY=1")
+	require.NoError(t, err)
+	assert.True(t, sent)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second*5)))
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "READY=1", string(buf[:n]))
+}
+
+func TestSystemdWatchdogInterval(t *testing.T) {
+	t.Setenv("WATCHDOG_USEC", "")
+	t.Setenv("WATCHDOG_PID", "")
+	assert.Equal(t, time.Duration(0), systemdWatchdogInterval())
+
+	t.Setenv("WATCHDOG_USEC", "2000000")
+	assert.Equal(t, time.Second, systemdWatchdogInterval())
+
+	t.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()+1))
+	assert.Equal(t, time.Duration(0), systemdWatchdogInterval())
+
+	t.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()))
+	assert.Equal(t, time.Second, systemdWatchdogInterval())
+}
@@ -117,6 +117,11 @@ func createCliCommand(cliOpts *common.CLIOpts) *cli.Command {
 				Value:   false,
 				Usage:   cliOpts.ExecTemplate("Print only the main components of a {{.ProductName}} config (input, pipeline, output) and omit all fields marked as advanced."),
 			},
+			&cli.BoolFlag{
+				Name:  "comments",
+				Value: false,
+				Usage: "Add a comment above each field containing a summary of its documentation.",
+			},
 		}, common.EnvFileAndTemplateFlags(cliOpts, false)...),
 		Usage: cliOpts.ExecTemplate("Create a new {{.ProductName}} config"),
 		Description: cliOpts.ExecTemplate(`
@@ -176,6 +181,9 @@ If the expression is omitted a default config is created.`)[1:],
 
 				err = spec.SanitiseYAML(&node, sanitConf)
 			}
+			if err == nil && c.Bool("comments") {
+				err = spec.DescribeYAML(cliOpts.Environment, &node)
+			}
 			if err == nil {
 				var configYAML []byte
 				if configYAML, err = docs.MarshalYAML(node); err == nil {
@@ -51,6 +51,15 @@ func TestCreate(t *testing.T) {
 				"stdout:",
 			},
 		},
+		{
+			name: "create with comments",
+			args: []string{"benthos", "create", "--comments", "-s", "generate/mapping/drop"},
+			contains: []string{
+				"generate:",
+				"# The number of generated messages that should be accumulated into each batch flushed at the specified interval.\n    batch_size:",
+				"# A list of processors to apply to messages.\n  processors:",
+			},
+		},
 		{
 			name: "create simple",
 			args: []string{"benthos", "create", "-s"},
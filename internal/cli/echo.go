@@ -23,6 +23,11 @@ func echoCliCommand(opts *common.CLIOpts) *cli.Command {
 			Aliases: []string{"r"},
 			Usage:   "pull in extra resources from a file, which can be referenced the same as resources defined in the main config, supports glob patterns (requires quotes)",
 		},
+		&cli.BoolFlag{
+			Name:  "defaults",
+			Value: false,
+			Usage: "include the default values of all fields of components that were omitted from the config",
+		},
 	}
 	flags = append(flags, common.EnvFileAndTemplateFlags(opts, false)...)
 
@@ -33,10 +38,11 @@ func echoCliCommand(opts *common.CLIOpts) *cli.Command {
 		Description: opts.ExecTemplate(`
 This simple command is useful for sanity checking a config if it isn't
 behaving as expected, as it shows you a normalised version after environment
-variables have been resolved:
+variables have been resolved and with the values of secret fields scrubbed:
 
   {{.BinaryName}} echo ./config.yaml | less
   {{.BinaryName}} echo --set 'input.generate.mapping=root.id = uuid_v4()'
+  {{.BinaryName}} echo --defaults ./config.yaml
   
   `)[1:],
 		Before: func(c *cli.Context) error {
@@ -55,12 +61,15 @@ variables have been resolved:
 			if err != nil {
 				return fmt.Errorf("configuration file read error: %w", err)
 			}
+			spec := opts.MainConfigSpecCtor()
+
 			var node yaml.Node
 			if err = node.Encode(pConf.Raw()); err == nil {
 				sanitConf := docs.NewSanitiseConfig(opts.Environment)
 				sanitConf.RemoveTypeField = true
 				sanitConf.ScrubSecrets = true
-				err = opts.MainConfigSpecCtor().SanitiseYAML(&node, sanitConf)
+				sanitConf.AddDefaults = c.Bool("defaults")
+				err = spec.SanitiseYAML(&node, sanitConf)
 			}
 			if err == nil {
 				var configYAML []byte
@@ -63,6 +63,30 @@ output:
 				"drop: {}",
 			},
 		},
+		{
+			name: "echo with defaults",
+			args: []string{"benthos", "echo", "--defaults", tFile("foo.yaml")},
+			files: map[string]string{
+				"foo.yaml": `
+input:
+  generate:
+    mapping: 'root.id = uuid_v4()'
+output:
+  http_client:
+    url: http://localhost:8080
+    basic_auth:
+      enabled: true
+      username: foo
+      password: bar
+`,
+			},
+			contains: []string{
+				"interval: 1s",
+				"batch_size: 1",
+				"verb: POST",
+				"password: '!!!SECRET_SCRUBBED!!!'",
+			},
+		},
 		{
 			name: "echo with set flag",
 			args: []string{"benthos", "echo", "--set", `input.generate.mapping=root.id = uuid_v4()`},
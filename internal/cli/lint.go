@@ -91,8 +91,13 @@ func lintFile(opts *common.CLIOpts, path string, skipEnvVarCheck bool, spec docs
 		return
 	}
 	for _, l := range lints {
+		source := path
+		if l.Path != "" {
+			// Lints of included files are reported against those files.
+			source, l.Path = l.Path, ""
+		}
 		pathLints = append(pathLints, pathLint{
-			source: path,
+			source: source,
 			lint:   l,
 		})
 	}
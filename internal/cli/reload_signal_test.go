@@ -0,0 +1,154 @@
+//go:build !windows && !wasm
+
+package cli_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	icli "github.com/redpanda-data/benthos/v4/internal/cli"
+	"github.com/redpanda-data/benthos/v4/internal/cli/common"
+
+	_ "github.com/redpanda-data/benthos/v4/public/components/io"
+	_ "github.com/redpanda-data/benthos/v4/public/components/pure"
+)
+
+func TestRunCLIReloadOnSignal(t *testing.T) {
+	tmpDir := t.TempDir()
+	confPath := filepath.Join(tmpDir, "foo.yaml")
+	outPath := filepath.Join(tmpDir, "out.txt")
+
+	writeConf := func(id string) {
+		require.NoError(t, os.WriteFile(confPath, fmt.Appendf(nil, `
+input:
+  generate:
+    mapping: 'root.id = "%v"'
+    interval: "50ms"
+output:
+  file:
+    codec: lines
+    path: %v
+`, id, outPath), 0o644))
+	}
+	writeConf("foobar")
+
+	// Ensures that the signal does not terminate the test process regardless
+	// of whether the service has begun listening for it.
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	defer signal.Stop(sigChan)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	opts := common.NewCLIOpts("1.2.3", "aaa")
+	opts.Stdout = io.Discard
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- icli.App(opts).RunContext(ctx, []string{"benthos", "run", confPath})
+	}()
+
+	outContains := func(s string) func() bool {
+		return func() bool {
+			data, _ := os.ReadFile(outPath)
+			return strings.Contains(string(data), s)
+		}
+	}
+	require.Eventually(t, outContains("foobar"), time.Second*10, time.Millisecond*50)
+
+	writeConf("bazbuz")
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	require.Eventually(t, outContains("bazbuz"), time.Second*10, time.Millisecond*50)
+
+	// The service must continue running with the reloaded config rather than
+	// terminating along with the replaced stream.
+	select {
+	case err := <-runErr:
+		t.Fatalf("Expected service to keep running, exited with: %v", err)
+	case <-time.After(time.Millisecond * 500):
+	}
+
+	cancel()
+	select {
+	case err := <-runErr:
+		assert.NoError(t, err)
+	case <-time.After(time.Second * 30):
+		t.Fatal("timed out waiting for service to stop")
+	}
+}
+
+func TestRunCLIReloadOnSignalInitFailure(t *testing.T) {
+	tmpDir := t.TempDir()
+	confPath := filepath.Join(tmpDir, "foo.yaml")
+	outPath := filepath.Join(tmpDir, "out.txt")
+
+	writeConf := func(interval string) {
+		require.NoError(t, os.WriteFile(confPath, fmt.Appendf(nil, `
+input:
+  generate:
+    mapping: 'root.id = "foobar"'
+    interval: "%v"
+output:
+  file:
+    codec: lines
+    path: %v
+`, interval, outPath), 0o644))
+	}
+	writeConf("50ms")
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	defer signal.Stop(sigChan)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	opts := common.NewCLIOpts("1.2.3", "aaa")
+	opts.Stdout = io.Discard
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- icli.App(opts).RunContext(ctx, []string{"benthos", "run", confPath})
+	}()
+
+	outLines := func() int {
+		data, _ := os.ReadFile(outPath)
+		return strings.Count(string(data), "foobar")
+	}
+	require.Eventually(t, func() bool { return outLines() > 0 }, time.Second*10, time.Millisecond*50)
+
+	// The interval passes linting but fails when the stream is built.
+	writeConf("not a duration")
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	// The previous config is restored and the service keeps running.
+	reloaded := outLines()
+	require.Eventually(t, func() bool { return outLines() > reloaded+5 }, time.Second*10, time.Millisecond*50)
+
+	select {
+	case err := <-runErr:
+		t.Fatalf("Expected service to keep running, exited with: %v", err)
+	default:
+	}
+
+	cancel()
+	select {
+	case err := <-runErr:
+		assert.NoError(t, err)
+	case <-time.After(time.Second * 30):
+		t.Fatal("timed out waiting for service to stop")
+	}
+}
@@ -61,6 +61,13 @@ func (s *sessionFS) MkdirAll(path string, perm fs.FileMode) error {
 	return s.backup.MkdirAll(path, perm)
 }
 
+func (s *sessionFS) Rename(oldpath, newpath string) error {
+	if s.backup == nil {
+		return errors.New("not implemented")
+	}
+	return ifs.Rename(s.backup, oldpath, newpath)
+}
+
 //------------------------------------------------------------------------------
 
 type sessionFile struct {
@@ -18,3 +18,13 @@ type V1 interface {
 	// is cancelled.
 	Close(ctx context.Context) error
 }
+
+// FeedbackReceiver is an optional interface that can be implemented by rate
+// limits in order to receive the outcome of each access of the rate limited
+// resource, allowing them to adapt their allowance.
+type FeedbackReceiver interface {
+	// Feedback reports the latency of a request made to the rate limited
+	// resource, and whether the resource signalled that requests should be
+	// throttled (e.g. a 429 or 5xx status code).
+	Feedback(ctx context.Context, latency time.Duration, throttled bool)
+}
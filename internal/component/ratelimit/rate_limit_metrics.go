@@ -38,6 +38,12 @@ func (r *metricsRateLimit) Access(ctx context.Context) (time.Duration, error) {
 	return tout, err
 }
 
+func (r *metricsRateLimit) Feedback(ctx context.Context, latency time.Duration, throttled bool) {
+	if f, ok := r.r.(FeedbackReceiver); ok {
+		f.Feedback(ctx, latency, throttled)
+	}
+}
+
 func (r *metricsRateLimit) Close(ctx context.Context) error {
 	return r.r.Close(ctx)
 }
@@ -30,17 +30,46 @@ func (e *ErrMissingEnvVars) Error() string {
 	return fmt.Sprintf("required environment variables were not set: %v", e.Variables)
 }
 
+// ErrRequiredEnvVars is returned when attempting environment variable
+// interpolations where variables marked as required with the `${FOO:?message}`
+// syntax are empty or missing. Unlike ErrMissingEnvVars this error should never
+// be downgraded to a lint, as the config explicitly demands the variables.
+type ErrRequiredEnvVars struct {
+	Variables []string
+
+	// Custom error messages provided with the `${FOO:?message}` syntax, keyed
+	// by the variable name.
+	Messages map[string]string
+}
+
+// Error returns an error message containing any custom messages of the
+// variables.
+func (e *ErrRequiredEnvVars) Error() string {
+	msg := fmt.Sprintf("required environment variables were not set: %v", e.Variables)
+	for _, v := range e.Variables {
+		if reason := e.Messages[v]; reason != "" {
+			msg += fmt.Sprintf(", %v: %v", v, reason)
+		}
+	}
+	return msg
+}
+
 // ReplaceEnvVariables will search a blob of data for the pattern `${FOO:bar}`,
 // where `FOO` is an environment variable name and `bar` is a default value. The
 // `bar` section (including the colon) can be left out if there is no
 // appropriate default value for the field.
 //
+// A variable can also be marked as required with the pattern `${FOO:?msg}`,
+// in which case an ErrRequiredEnvVars error containing `msg` is returned when
+// the variable is empty or does not exist.
+//
 // For each aforementioned pattern found in the blob the contents of the
 // respective environment variable will be read and will replace the pattern. If
 // the environment variable is empty or does not exist then either the default
 // value is used or the field will be left empty.
 func (r *Reader) ReplaceEnvVariables(ctx context.Context, inBytes []byte) (replaced []byte, err error) {
 	var missingVarsErr ErrMissingEnvVars
+	var requiredVarsErr ErrRequiredEnvVars
 
 	replaced = envRegex.ReplaceAllFunc(inBytes, func(content []byte) []byte {
 		var value string
@@ -55,7 +84,18 @@ func (r *Reader) ReplaceEnvVariables(ctx context.Context, inBytes []byte) (repla
 				targetVar := content[2:colonIndex]
 				defaultVal := content[colonIndex+1 : len(content)-1]
 				value, _ = r.envLookupFunc(ctx, string(targetVar))
-				if value == "" {
+				if len(defaultVal) > 0 && defaultVal[0] == '?' {
+					if value == "" {
+						varName := string(targetVar)
+						requiredVarsErr.Variables = append(requiredVarsErr.Variables, varName)
+						if reason := string(defaultVal[1:]); reason != "" {
+							if requiredVarsErr.Messages == nil {
+								requiredVarsErr.Messages = map[string]string{}
+							}
+							requiredVarsErr.Messages[varName] = reason
+						}
+					}
+				} else if value == "" {
 					value = string(defaultVal)
 				}
 			}
@@ -67,6 +107,9 @@ func (r *Reader) ReplaceEnvVariables(ctx context.Context, inBytes []byte) (repla
 	})
 	replaced = escapedEnvRegex.ReplaceAll(replaced, []byte("$$$1"))
 
+	if len(requiredVarsErr.Variables) > 0 {
+		return nil, &requiredVarsErr
+	}
 	if len(missingVarsErr.Variables) > 0 {
 		missingVarsErr.BestAttempt = replaced
 		err = &missingVarsErr
@@ -2,6 +2,9 @@ package config
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -51,6 +54,9 @@ func TestEnvSwapping(t *testing.T) {
 		"foo ${BENTHOS_TEST_THIS_DOESNT_EXIST_LOL} baz":                            {errContains: "required environment variables were not set: [BENTHOS_TEST_THIS_DOESNT_EXIST_LOL]"},
 		"foo ${BENTHOS_TEST_NOPE_A} baz ${BENTHOS_TEST_NOPE_B} buz":                {errContains: "required environment variables were not set: [BENTHOS_TEST_NOPE_A BENTHOS_TEST_NOPE_B]"},
 		"foo ${DOES_NOT_EXIST::} baz":                                              {result: "foo : baz"},
+		"foo ${BENTHOS.TEST.FOO:?must be set} baz":                                 {result: "foo testfoo baz"},
+		"foo ${BENTHOS_TEST_FOO:?} baz":                                            {errContains: "required environment variables were not set: [BENTHOS_TEST_FOO]"},
+		"foo ${DOES_NOT_EXIST:?the api key is required} baz":                       {errContains: "required environment variables were not set: [DOES_NOT_EXIST], DOES_NOT_EXIST: the api key is required"},
 	}
 
 	for in, exp := range tests {
@@ -67,3 +73,57 @@ func TestEnvSwapping(t *testing.T) {
 		}
 	}
 }
+
+func TestEnvRequiredNotDefault(t *testing.T) {
+	r := NewReader("", nil, OptUseEnvLookupFunc(func(ctx context.Context, s string) (string, bool) {
+		return "", false
+	}))
+
+	// Prior to the `${FOO:?message}` syntax a value beginning with `?` was
+	// used as the default, which is no longer the case.
+	out, err := r.ReplaceEnvVariables(context.Background(), []byte("foo: ${BENTHOS_TEST_FOO:?bar}"))
+	assert.Nil(t, out)
+
+	var errRequired *ErrRequiredEnvVars
+	require.True(t, errors.As(err, &errRequired))
+	assert.Equal(t, []string{"BENTHOS_TEST_FOO"}, errRequired.Variables)
+	assert.Equal(t, map[string]string{"BENTHOS_TEST_FOO": "bar"}, errRequired.Messages)
+}
+
+func TestEnvRequiredNotDowngraded(t *testing.T) {
+	envFn := func(ctx context.Context, s string) (string, bool) {
+		return "", false
+	}
+
+	dir := t.TempDir()
+	for name, exp := range map[string]struct {
+		content  string
+		required bool
+	}{
+		"missing":  {content: "foo: ${BENTHOS_TEST_FOO}", required: false},
+		"required": {content: "foo: ${BENTHOS_TEST_FOO:?must be set}", required: true},
+	} {
+		name, exp := name, exp
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name+".yaml")
+			require.NoError(t, os.WriteFile(path, []byte(exp.content), 0o644))
+
+			_, lints, _, err := NewReader("", nil, OptUseEnvLookupFunc(envFn)).ReadFileEnvSwap(context.Background(), path)
+			if !exp.required {
+				require.NoError(t, err)
+				require.Len(t, lints, 1)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Empty(t, lints)
+
+			var errRequired *ErrRequiredEnvVars
+			require.True(t, errors.As(err, &errRequired))
+			assert.Equal(t, []string{"BENTHOS_TEST_FOO"}, errRequired.Variables)
+
+			var errMissing *ErrMissingEnvVars
+			assert.False(t, errors.As(err, &errMissing))
+		})
+	}
+}
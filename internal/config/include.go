@@ -0,0 +1,232 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/redpanda-data/benthos/v4/internal/docs"
+)
+
+// includeKey is the config key used for importing the contents of other config
+// files into the object it is placed within.
+const includeKey = "$include"
+
+// includeSources maps the nodes of a config that were parsed from included
+// files to the paths of those files.
+type includeSources map[*yaml.Node]string
+
+// lintCtx returns a linting context where lints of included nodes are
+// attributed to the files they came from.
+func (s includeSources) lintCtx(ctx docs.LintContext) docs.LintContext {
+	return ctx.WithNodeSources(s)
+}
+
+// add records the path of a node and all of its children, skipping any that
+// have already been recorded by a nested include.
+func (s includeSources) add(path string, node *yaml.Node) {
+	if _, exists := s[node]; !exists {
+		s[node] = path
+	}
+	for _, child := range node.Content {
+		s.add(path, child)
+	}
+}
+
+// lintString formats a lint of a config read from a path, unless the lint
+// originates from an included file.
+func lintString(path string, l docs.Lint) string {
+	if l.Path != "" {
+		return l.Error()
+	}
+	return path + l.Error()
+}
+
+// resolveIncludes walks a parsed config node read from the provided path and
+// expands any `$include` keys found within objects. The value of an include
+// key is either a single file path or an array of file paths, which are
+// resolved relative to the directory of the file that includes them.
+//
+// The included files are deep merged in the order that they are listed, with
+// later files overriding earlier ones, and finally the fields of the object
+// containing the include key are merged on top. Objects are merged key by key
+// whereas all other values, including arrays, are replaced outright.
+//
+// The returned sources identify the nodes that came from included files, and
+// should be provided to the linting context of the resulting node.
+func (r *Reader) resolveIncludes(ctx context.Context, path string, node *yaml.Node) (srcs includeSources, lints []docs.Lint, err error) {
+	srcs = includeSources{}
+	lints, err = r.resolveIncludesStack(ctx, srcs, []string{filepath.Clean(path)}, node)
+	return
+}
+
+func (r *Reader) resolveIncludesStack(ctx context.Context, srcs includeSources, stack []string, node *yaml.Node) (lints []docs.Lint, err error) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			var cLints []docs.Lint
+			if cLints, err = r.resolveIncludesStack(ctx, srcs, stack, child); err != nil {
+				return
+			}
+			lints = append(lints, cLints...)
+		}
+		return
+	case yaml.MappingNode:
+	default:
+		return
+	}
+
+	var includePaths []string
+	var content []*yaml.Node
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == includeKey {
+			if includePaths, err = includePathsFromNode(stack[len(stack)-1], node.Content[i+1]); err != nil {
+				return
+			}
+			continue
+		}
+
+		var cLints []docs.Lint
+		if cLints, err = r.resolveIncludesStack(ctx, srcs, stack, node.Content[i+1]); err != nil {
+			return
+		}
+		lints = append(lints, cLints...)
+		content = append(content, node.Content[i], node.Content[i+1])
+	}
+	if len(includePaths) == 0 {
+		node.Content = content
+		return
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode}
+	for _, p := range includePaths {
+		if slices.Contains(stack, p) {
+			return nil, fmt.Errorf("cyclic include of file %v", p)
+		}
+
+		var incBytes []byte
+		var incLints []docs.Lint
+		var modTime time.Time
+		if incBytes, incLints, modTime, err = r.ReadFileEnvSwap(ctx, p); err != nil {
+			return nil, fmt.Errorf("failed to read included file: %w", err)
+		}
+		r.modTimeLastRead[p] = modTime
+		r.addInclude(p, stack[0])
+
+		for _, l := range incLints {
+			l.Path = p
+			lints = append(lints, l)
+		}
+
+		var incNode *yaml.Node
+		if incNode, err = docs.UnmarshalYAML(incBytes); err != nil {
+			return nil, fmt.Errorf("failed to parse included file %v: %w", p, err)
+		}
+		if incNode.Kind == 0 {
+			// Empty file
+			continue
+		}
+		if incNode.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("included file %v must contain an object", p)
+		}
+
+		var cLints []docs.Lint
+		if cLints, err = r.resolveIncludesStack(ctx, srcs, append(stack, p), incNode); err != nil {
+			return nil, fmt.Errorf("%v: %w", p, err)
+		}
+		lints = append(lints, cLints...)
+		srcs.add(p, incNode)
+		mergeYAMLMappings(merged, incNode)
+	}
+
+	mergeYAMLMappings(merged, &yaml.Node{Kind: yaml.MappingNode, Content: content})
+	node.Content = merged.Content
+	return
+}
+
+// addInclude records that a file has been included by a config file so that
+// changes to it can be watched.
+func (r *Reader) addInclude(path, includedBy string) {
+	r.includeMut.Lock()
+	defer r.includeMut.Unlock()
+
+	if !slices.Contains(r.includedBy[path], includedBy) {
+		r.includedBy[path] = append(r.includedBy[path], includedBy)
+	}
+}
+
+// includePaths returns all files that have been included by config files.
+func (r *Reader) includePaths() []string {
+	r.includeMut.Lock()
+	defer r.includeMut.Unlock()
+
+	paths := make([]string, 0, len(r.includedBy))
+	for p := range r.includedBy {
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// includeOwners returns the config files that include a file.
+func (r *Reader) includeOwners(path string) []string {
+	r.includeMut.Lock()
+	defer r.includeMut.Unlock()
+
+	return slices.Clone(r.includedBy[path])
+}
+
+func includePathsFromNode(fromPath string, node *yaml.Node) ([]string, error) {
+	var paths []string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		paths = []string{node.Value}
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			if child.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %v: expected %v to contain file paths", child.Line, includeKey)
+			}
+			paths = append(paths, child.Value)
+		}
+	default:
+		return nil, fmt.Errorf("line %v: expected %v to be a file path or an array of file paths", node.Line, includeKey)
+	}
+
+	baseDir := filepath.Dir(fromPath)
+	for i, p := range paths {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(baseDir, p)
+		}
+		paths[i] = filepath.Clean(p)
+	}
+	return paths, nil
+}
+
+// mergeYAMLMappings deep merges the fields of the mapping node src into dst,
+// where values of src take precedence unless both values are also mappings.
+func mergeYAMLMappings(dst, src *yaml.Node) {
+	for i := 0; i < len(src.Content)-1; i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+
+		existing := -1
+		for j := 0; j < len(dst.Content)-1; j += 2 {
+			if dst.Content[j].Value == key.Value {
+				existing = j + 1
+				break
+			}
+		}
+
+		if existing == -1 {
+			dst.Content = append(dst.Content, key, value)
+			continue
+		}
+		if dst.Content[existing].Kind == yaml.MappingNode && value.Kind == yaml.MappingNode {
+			mergeYAMLMappings(dst.Content[existing], value)
+			continue
+		}
+		dst.Content[existing] = value
+	}
+}
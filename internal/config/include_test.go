@@ -0,0 +1,148 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/redpanda-data/benthos/v4/internal/bundle"
+	"github.com/redpanda-data/benthos/v4/internal/docs"
+)
+
+func TestReaderIncludes(t *testing.T) {
+	testFS := &testFS{m: fstest.MapFS{
+		"main.yaml": &fstest.MapFile{
+			Data: []byte(`
+$include: [ shared/base.yaml, shared/resources.yaml ]
+
+input:
+  label: mainin
+
+output:
+  $include: shared/output.yaml
+  label: mainout
+`),
+		},
+		"shared/base.yaml": &fstest.MapFile{
+			Data: []byte(`
+input:
+  label: basein
+  inproc: foo
+
+output:
+  label: baseout
+  inproc: bar
+
+http:
+  enabled: false
+`),
+		},
+		"shared/resources.yaml": &fstest.MapFile{
+			Data: []byte(`
+$include: processors.yaml
+http:
+  address: 0.0.0.0:4196
+`),
+		},
+		"shared/processors.yaml": &fstest.MapFile{
+			Data: []byte(`
+processor_resources:
+  - label: a
+    mapping: 'root = content() + " a1"'
+`),
+		},
+		"shared/output.yaml": &fstest.MapFile{
+			Data: []byte(`
+inproc: baz
+`),
+		},
+	}}
+
+	rdr := newDummyReader("main.yaml", nil, OptUseFS(testFS))
+
+	conf, _, lints, err := rdr.Read()
+	require.NoError(t, err)
+	require.Empty(t, lints)
+
+	assert.Equal(t, "mainin", conf.Input.Label)
+	assert.Equal(t, "inproc", conf.Input.Type)
+	assert.Equal(t, "mainout", conf.Output.Label)
+	require.IsType(t, &yaml.Node{}, conf.Output.Plugin)
+	assert.Equal(t, "baz", conf.Output.Plugin.(*yaml.Node).Value)
+
+	assert.False(t, conf.HTTP.Enabled)
+	assert.Equal(t, "0.0.0.0:4196", conf.HTTP.Address)
+
+	require.Len(t, conf.ResourceProcessors, 1)
+	assert.Equal(t, "a", conf.ResourceProcessors[0].Label)
+}
+
+func TestReaderIncludesCyclic(t *testing.T) {
+	testFS := &testFS{m: fstest.MapFS{
+		"main.yaml": &fstest.MapFile{
+			Data: []byte(`
+$include: a.yaml
+`),
+		},
+		"a.yaml": &fstest.MapFile{
+			Data: []byte(`
+$include: main.yaml
+`),
+		},
+	}}
+
+	rdr := newDummyReader("main.yaml", nil, OptUseFS(testFS))
+
+	_, _, _, err := rdr.Read()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cyclic include of file main.yaml")
+}
+
+func TestReaderIncludesLintPaths(t *testing.T) {
+	testFS := &testFS{m: fstest.MapFS{
+		"main.yaml": &fstest.MapFile{
+			Data: []byte(`
+$include: shared/base.yaml
+
+input:
+  label: mainin
+  nope: true
+`),
+		},
+		"shared/base.yaml": &fstest.MapFile{
+			Data: []byte(`
+input:
+  inproc: foo
+
+output:
+  inproc: bar
+  alsonope: true
+`),
+		},
+	}}
+
+	rdr := newDummyReader("main.yaml", nil, OptUseFS(testFS))
+
+	conf, _, lints, err := rdr.Read()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"main.yaml(6,1) field nope is invalid when the component type is inproc (input)",
+		"shared/base.yaml(7,1) field alsonope is invalid when the component type is inproc (output)",
+	}, lints)
+	assert.Equal(t, "mainin", conf.Input.Label)
+
+	_, dLints, err := rdr.ReadYAMLFileLinted(context.Background(), Spec(), "main.yaml", false, docs.NewLintConfig(bundle.GlobalEnvironment))
+	require.NoError(t, err)
+	require.Len(t, dLints, 2)
+
+	var paths []string
+	for _, l := range dLints {
+		paths = append(paths, fmt.Sprintf("%v:%v", l.Path, l.Line))
+	}
+	assert.ElementsMatch(t, []string{":6", "shared/base.yaml:7"}, paths)
+}
@@ -20,6 +20,17 @@ func (r *Reader) ReadYAMLFileLinted(ctx context.Context, spec docs.FieldSpecs, p
 		return Type{}, nil, err
 	}
 
+	cNode, err := docs.UnmarshalYAML(configBytes)
+	if err != nil {
+		return Type{}, nil, err
+	}
+
+	srcs, incLints, err := r.resolveIncludes(ctx, path, cNode)
+	if err != nil {
+		return Type{}, nil, err
+	}
+	lints = append(lints, incLints...)
+
 	if skipEnvVarCheck {
 		var newLints []docs.Lint
 		for _, l := range lints {
@@ -30,9 +41,8 @@ func (r *Reader) ReadYAMLFileLinted(ctx context.Context, spec docs.FieldSpecs, p
 		lints = newLints
 	}
 
-	cNode, err := docs.UnmarshalYAML(configBytes)
-	if err != nil {
-		return Type{}, nil, err
+	if !bytes.HasPrefix(configBytes, []byte("# BENTHOS LINT DISABLE")) {
+		lints = append(lints, spec.LintYAML(srcs.lintCtx(docs.NewLintContext(lConf)), cNode)...)
 	}
 
 	var rawSource any
@@ -47,10 +57,6 @@ func (r *Reader) ReadYAMLFileLinted(ctx context.Context, spec docs.FieldSpecs, p
 	if err != nil {
 		return Type{}, nil, err
 	}
-
-	if !bytes.HasPrefix(configBytes, []byte("# BENTHOS LINT DISABLE")) {
-		lints = append(lints, spec.LintYAML(docs.NewLintContext(lConf), cNode)...)
-	}
 	return conf, lints, nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Jeffail/gabs/v2"
@@ -65,6 +66,11 @@ type Reader struct {
 
 	modTimeLastRead map[string]time.Time
 
+	// Tracks the files included by config files, keyed by the included path
+	// and pointing to the files that include them.
+	includedBy map[string][]string
+	includeMut sync.Mutex
+
 	// Controls whether the main config should include input, output, etc.
 	streamsMode bool
 
@@ -84,6 +90,10 @@ type Reader struct {
 	streamUpdateFn StreamUpdateFunc
 	watcher        fileWatcher
 
+	// Serialises updates triggered by the file watcher and by explicit reload
+	// requests.
+	updateMut sync.Mutex
+
 	changeFlushPeriod  time.Duration
 	changeDelayPeriod  time.Duration
 	filesRefreshPeriod time.Duration
@@ -104,6 +114,7 @@ func NewReader(mainPath string, resourcePaths []string, opts ...OptFunc) *Reader
 		mainPath:           mainPath,
 		resourcePaths:      resourcePaths,
 		modTimeLastRead:    map[string]time.Time{},
+		includedBy:         map[string][]string{},
 		streamFileInfo:     map[string]streamFileInfo{},
 		resourceFileInfo:   map[string]resourceFileInfo{},
 		resourceSources:    newResourceSourceInfo(),
@@ -256,6 +267,44 @@ func (r *Reader) SubscribeStreamChanges(fn StreamUpdateFunc) error {
 	return nil
 }
 
+// TriggerReload attempts to re-read all config files, regardless of whether
+// they have been modified since they were last read, and applies the results
+// through the provided manager and the closures registered with either
+// SubscribeConfigChanges or SubscribeStreamChanges. This allows a reload to be
+// requested explicitly, e.g. when the process receives a SIGHUP.
+func (r *Reader) TriggerReload(mgr bundle.NewManagement, strict bool) error {
+	r.updateMut.Lock()
+	defer r.updateMut.Unlock()
+
+	var errs []error
+	if !r.streamsMode && r.mainPath != "" {
+		if err := r.TriggerMainUpdate(mgr, strict, r.mainPath); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	resourcePaths, err := r.resourcePathsExpanded()
+	if err != nil {
+		return err
+	}
+	for _, p := range resourcePaths {
+		if err := r.TriggerResourceUpdate(mgr, strict, p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	streamsPaths, err := r.streamPathsExpanded()
+	if err != nil {
+		return err
+	}
+	for _, p := range streamsPaths {
+		if err := r.TriggerStreamUpdate(mgr, strict, p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Close the reader, when this method exits all reloading will be stopped.
 func (r *Reader) Close(ctx context.Context) error {
 	if r.watcher != nil {
@@ -299,6 +348,7 @@ func (r *Reader) readMain(mainPath string) (conf Type, pConf *docs.ParsedConfig,
 
 	var rawNode *yaml.Node
 	var confBytes []byte
+	var srcs includeSources
 	if mainPath != "" {
 		var dLints []docs.Lint
 		var modTime time.Time
@@ -313,6 +363,12 @@ func (r *Reader) readMain(mainPath string) (conf Type, pConf *docs.ParsedConfig,
 		if rawNode, err = docs.UnmarshalYAML(confBytes); err != nil {
 			return
 		}
+		if srcs, dLints, err = r.resolveIncludes(context.TODO(), mainPath, rawNode); err != nil {
+			return
+		}
+		for _, l := range dLints {
+			lints = append(lints, lintString("", l))
+		}
 	} else {
 		var tmpNode yaml.Node
 		if err = tmpNode.Encode(map[string]any{}); err != nil {
@@ -332,9 +388,8 @@ func (r *Reader) readMain(mainPath string) (conf Type, pConf *docs.ParsedConfig,
 	}
 
 	if !bytes.HasPrefix(confBytes, []byte("# BENTHOS LINT DISABLE")) {
-		lintFilePrefix := mainPath
-		for _, lint := range confSpec.LintYAML(r.lintCtx(), rawNode) {
-			lints = append(lints, fmt.Sprintf("%v%v", lintFilePrefix, lint.Error()))
+		for _, lint := range confSpec.LintYAML(srcs.lintCtx(r.lintCtx()), rawNode) {
+			lints = append(lints, lintString(mainPath, lint))
 		}
 	}
 
@@ -239,3 +239,68 @@ processor_resources:
 	assert.True(t, testMgr.ProbeProcessor("c"))
 	assert.True(t, testMgr.ProbeProcessor("d"))
 }
+
+func TestCustomFileTriggerReload(t *testing.T) {
+	testFS := &testFS{m: fstest.MapFS{
+		"foo_main.yaml": &fstest.MapFile{
+			Data: []byte(`
+input:
+  label: fooin
+  inproc: foo
+
+output:
+  label: fooout
+  inproc: bar
+`),
+		},
+		"a.yaml": &fstest.MapFile{
+			Data: []byte(`
+processor_resources:
+  - label: a
+    mapping: 'root = content() + " a1"'
+`),
+		},
+	}}
+	rdr := newDummyReader("foo_main.yaml", []string{"a.yaml"}, OptUseFS(testFS))
+
+	conf, _, lints, err := rdr.Read()
+	require.NoError(t, err)
+	require.Empty(t, lints)
+
+	testMgr, err := manager.New(conf.ResourceConfig)
+	require.NoError(t, err)
+
+	var updatedConf *stream.Config
+	require.NoError(t, rdr.SubscribeConfigChanges(func(conf *Type) error {
+		updatedConf = &conf.Config
+		return nil
+	}))
+
+	testFS.m["foo_main.yaml"] = &fstest.MapFile{
+		Data: []byte(`
+input:
+  label: foointwo
+  inproc: foo
+
+output:
+  label: fooouttwo
+  inproc: bar
+`),
+	}
+	testFS.m["a.yaml"] = &fstest.MapFile{
+		Data: []byte(`
+processor_resources:
+  - label: b
+    mapping: 'root = content() + " b1"'
+`),
+	}
+
+	require.NoError(t, rdr.TriggerReload(testMgr, true))
+
+	require.NotNil(t, updatedConf)
+	assert.Equal(t, "foointwo", updatedConf.Input.Label)
+	assert.Equal(t, "fooouttwo", updatedConf.Output.Label)
+
+	assert.False(t, testMgr.ProbeProcessor("a"))
+	assert.True(t, testMgr.ProbeProcessor("b"))
+}
@@ -219,13 +219,20 @@ func (r *Reader) readResource(path string) (conf manager.ResourceConfig, lints [
 	if rawNode, err = docs.UnmarshalYAML(confBytes); err != nil {
 		return
 	}
+	var srcs includeSources
+	if srcs, dLints, err = r.resolveIncludes(context.TODO(), path, rawNode); err != nil {
+		return
+	}
+	for _, l := range dLints {
+		lints = append(lints, lintString("", l))
+	}
 
 	spec := append(docs.FieldSpecs{
 		test.ConfigSpec(),
 	}, r.specResources...)
 	if !bytes.HasPrefix(confBytes, []byte("# BENTHOS LINT DISABLE")) {
-		for _, lint := range spec.LintYAML(r.lintCtx(), rawNode) {
-			lints = append(lints, fmt.Sprintf("%v%v", path, lint.Error()))
+		for _, lint := range spec.LintYAML(srcs.lintCtx(r.lintCtx()), rawNode) {
+			lints = append(lints, lintString(path, lint))
 		}
 	}
 
@@ -61,7 +61,7 @@ func observabilityFields() docs.FieldSpecs {
 			"none": map[string]any{},
 		}),
 		docs.FieldString(fieldSystemCloseDelay, "A period of time to wait for metrics and traces to be pulled or pushed from the process.").HasDefault("0s"),
-		docs.FieldString(fieldSystemCloseTimeout, "The maximum period of time to wait for a clean shutdown. If this time is exceeded Redpanda Connect will forcefully close.").HasDefault("20s"),
+		docs.FieldString(fieldSystemCloseTimeout, "The maximum period of time to wait for a clean shutdown, during which inputs stop consuming and buffers, processors and outputs are given the chance to finish with in-flight messages. If this time is exceeded Redpanda Connect will forcefully close, and messages that were not acknowledged are not committed at their source.").HasDefault("20s"),
 	}
 }
 
@@ -59,6 +59,13 @@ func (r *Reader) readStreamFileConfig(path string) (conf stream.Config, lints []
 	if rawNode, err = docs.UnmarshalYAML(confBytes); err != nil {
 		return
 	}
+	var srcs includeSources
+	if srcs, dLints, err = r.resolveIncludes(context.TODO(), path, rawNode); err != nil {
+		return
+	}
+	for _, l := range dLints {
+		lints = append(lints, lintString("", l))
+	}
 
 	var rawSource any
 	_ = rawNode.Decode(&rawSource)
@@ -67,8 +74,8 @@ func (r *Reader) readStreamFileConfig(path string) (conf stream.Config, lints []
 	confSpec = append(confSpec, test.ConfigSpec())
 
 	if !bytes.HasPrefix(confBytes, []byte("# BENTHOS LINT DISABLE")) {
-		for _, lint := range confSpec.LintYAML(r.lintCtx(), rawNode) {
-			lints = append(lints, fmt.Sprintf("%v%v", path, lint.Error()))
+		for _, lint := range confSpec.LintYAML(srcs.lintCtx(r.lintCtx()), rawNode) {
+			lints = append(lints, lintString(path, lint))
 		}
 	}
 
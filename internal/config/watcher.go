@@ -125,6 +125,14 @@ func (r *Reader) BeginFileWatching(mgr bundle.NewManagement, strict bool) error
 		if err := addNotWatching(resourcePaths); err != nil {
 			return err
 		}
+
+		for _, p := range r.includePaths() {
+			if _, err := r.fs.Stat(p); err == nil {
+				if err := addNotWatching([]string{p}); err != nil {
+					return err
+				}
+			}
+		}
 		return nil
 	}
 
@@ -167,25 +175,19 @@ func (r *Reader) BeginFileWatching(mgr bundle.NewManagement, strict bool) error
 					collapsedChanges[cleanPath] = fileChange{at: time.Now()}
 				}
 			case <-changeTicker.C:
+				r.updateMut.Lock()
 				for nameClean, change := range collapsedChanges {
 					if time.Since(change.at) < r.changeDelayPeriod {
 						continue
 					}
-					var succeeded bool
-					if nameClean == r.mainPath {
-						succeeded = !ShouldReread(r.TriggerMainUpdate(mgr, strict, r.mainPath))
-					} else if _, exists := r.streamFileInfo[nameClean]; exists {
-						succeeded = !ShouldReread(r.TriggerStreamUpdate(mgr, strict, nameClean))
-					} else {
-						succeeded = !ShouldReread(r.TriggerResourceUpdate(mgr, strict, nameClean))
-					}
-					if succeeded {
+					if !ShouldReread(r.triggerFileUpdate(mgr, strict, nameClean)) {
 						delete(collapsedChanges, nameClean)
 					} else {
 						change.at = time.Now()
 						collapsedChanges[nameClean] = change
 					}
 				}
+				r.updateMut.Unlock()
 			case <-filesTicker.C:
 				if err := refreshFiles(); err != nil {
 					mgr.Logger().Error("Failed to refresh watched paths: %v", err)
@@ -200,3 +202,26 @@ func (r *Reader) BeginFileWatching(mgr bundle.NewManagement, strict bool) error
 	}()
 	return nil
 }
+
+// triggerFileUpdate re-reads a changed file, where changes to a file included
+// by other config files are applied by re-reading the files that include it.
+func (r *Reader) triggerFileUpdate(mgr bundle.NewManagement, strict bool, name string) error {
+	if name == r.mainPath {
+		return r.TriggerMainUpdate(mgr, strict, name)
+	}
+	if _, exists := r.streamFileInfo[name]; exists {
+		return r.TriggerStreamUpdate(mgr, strict, name)
+	}
+	if _, exists := r.resourceFileInfo[name]; !exists {
+		if owners := r.includeOwners(name); len(owners) > 0 {
+			var errs []error
+			for _, owner := range owners {
+				if err := r.triggerFileUpdate(mgr, strict, owner); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return errors.Join(errs...)
+		}
+	}
+	return r.TriggerResourceUpdate(mgr, strict, name)
+}
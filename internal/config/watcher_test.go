@@ -64,6 +64,59 @@ output:
 	assert.Equal(t, "drop", updatedConf.Output.Type)
 }
 
+func TestReaderIncludeFileWatching(t *testing.T) {
+	confDir := t.TempDir()
+
+	confFilePath := filepath.Join(confDir, "main.yaml")
+	require.NoError(t, os.WriteFile(confFilePath, []byte(`
+$include: output.yaml
+input:
+  generate:
+    mapping: 'root = "foo"'
+`), 0o644))
+
+	incFilePath := filepath.Join(confDir, "output.yaml")
+	require.NoError(t, os.WriteFile(incFilePath, []byte(`
+output:
+  drop: {}
+`), 0o644))
+
+	rdr := newDummyReader(confFilePath, nil)
+
+	conf, _, lints, err := rdr.Read()
+	require.NoError(t, err)
+	require.Empty(t, lints)
+	assert.Equal(t, "drop", conf.Output.Type)
+
+	changeChan := make(chan struct{})
+	once := sync.Once{}
+	var updatedConf stream.Config
+	require.NoError(t, rdr.SubscribeConfigChanges(func(conf *Type) error {
+		updatedConf = conf.Config
+		once.Do(func() { close(changeChan) })
+		return nil
+	}))
+
+	testMgr, err := manager.New(manager.ResourceConfig{})
+	require.NoError(t, err)
+	require.NoError(t, rdr.BeginFileWatching(testMgr, true))
+
+	// Modifying the included file reloads the main config
+	require.NoError(t, os.WriteFile(incFilePath, []byte(`
+output:
+  reject: nope
+`), 0o644))
+
+	select {
+	case <-changeChan:
+	case <-time.After(time.Second * 5):
+		require.FailNow(t, "Expected a config change to be triggered")
+	}
+
+	assert.Equal(t, "generate", updatedConf.Input.Type)
+	assert.Equal(t, "reject", updatedConf.Output.Type)
+}
+
 func TestReaderFileWatchingSymlinkReplace(t *testing.T) {
 	dummyConfig := []byte(`
 input:
@@ -131,6 +131,7 @@ type SanitiseConfig struct {
 	RemoveDeprecated bool
 	ScrubSecrets     bool
 	ForExample       bool
+	AddDefaults      bool
 	Filter           FieldFilter
 	DocsProvider     Provider
 }
@@ -0,0 +1,85 @@
+package docs
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DescribeYAML adds a head comment to each field of a YAML config containing
+// a short summary of the field description, including the fields of any
+// components found within the config. Fields that already have a head comment
+// are left unchanged.
+func (f FieldSpecs) DescribeYAML(prov Provider, node *yaml.Node) error {
+	node = unwrapDocumentNode(node)
+	f.describeYAML(node)
+
+	return f.WalkComponentsYAML(WalkComponentConfig{
+		Provider: prov,
+		Func: func(c WalkedComponent) error {
+			for i := 0; i < len(c.Value.Content)-1; i += 2 {
+				if c.Value.Content[i].Value == c.Name {
+					c.spec.Config.describeYAML(c.Value.Content[i+1])
+					break
+				}
+			}
+			return nil
+		},
+	}, node)
+}
+
+func (f FieldSpec) describeYAML(node *yaml.Node) {
+	if _, isCore := f.Type.IsCoreComponent(); isCore || len(f.Children) == 0 {
+		return
+	}
+	switch f.Kind {
+	case Kind2DArray:
+		for i := 0; i < len(node.Content); i++ {
+			for j := 0; j < len(node.Content[i].Content); j++ {
+				f.Children.describeYAML(node.Content[i].Content[j])
+			}
+		}
+	case KindArray:
+		for i := 0; i < len(node.Content); i++ {
+			f.Children.describeYAML(node.Content[i])
+		}
+	case KindMap:
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			f.Children.describeYAML(node.Content[i+1])
+		}
+	default:
+		f.Children.describeYAML(node)
+	}
+}
+
+func (f FieldSpecs) describeYAML(node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		key := node.Content[i]
+		for _, field := range f {
+			if field.Name != key.Value {
+				continue
+			}
+			if key.HeadComment == "" {
+				key.HeadComment = descriptionSummary(field.Description)
+			}
+			field.describeYAML(node.Content[i+1])
+			break
+		}
+	}
+}
+
+// descriptionSummary returns the first sentence of a field description with
+// any line breaks removed.
+func descriptionSummary(desc string) string {
+	desc = strings.TrimSpace(desc)
+	if i := strings.Index(desc, "\n\n"); i != -1 {
+		desc = desc[:i]
+	}
+	if i := strings.Index(desc, ". "); i != -1 {
+		desc = desc[:i+1]
+	}
+	return strings.Join(strings.Fields(desc), " ")
+}
@@ -0,0 +1,62 @@
+package docs_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/internal/docs"
+)
+
+func TestDescribeYAML(t *testing.T) {
+	mockProv := docs.NewMappedDocsProvider()
+	mockProv.RegisterDocs(docs.ComponentSpec{
+		Name: "generate",
+		Type: docs.TypeInput,
+		Config: docs.FieldComponent().WithChildren(
+			docs.FieldString("mapping", "A mapping to execute. Some more details\nabout mappings."),
+			docs.FieldObject("nested", "A nested object.").WithChildren(
+				docs.FieldInt("count", "A count."),
+			).Array(),
+		),
+	})
+
+	spec := docs.FieldSpecs{
+		docs.FieldInput("input", "An input\nto read from."),
+		docs.FieldString("name", "A name.\n\nWith more paragraphs."),
+		docs.FieldString("title", "A title.\n\nWith more paragraphs."),
+	}
+
+	node, err := docs.UnmarshalYAML([]byte(`
+input:
+  generate:
+    mapping: root = "hello"
+    nested:
+      - count: 10
+# An existing comment.
+name: foo
+title: bar
+`))
+	require.NoError(t, err)
+
+	require.NoError(t, spec.DescribeYAML(mockProv, node))
+
+	b, err := docs.MarshalYAML(*node)
+	require.NoError(t, err)
+
+	assert.Equal(t, `# An input to read from.
+input:
+  generate:
+    # A mapping to execute.
+    mapping: root = "hello"
+    # A nested object.
+    nested:
+      - # A count.
+        count: 10
+# An existing comment.
+name: foo
+# A title.
+title: bar
+`, string(b))
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/redpanda-data/benthos/v4/internal/value"
 	"github.com/redpanda-data/benthos/v4/public/bloblang"
 )
@@ -698,6 +700,10 @@ type LintContext struct {
 	// A map of label names to the line they were defined at.
 	labelsToLine map[string]int
 
+	// An optional map of nodes to the path of the file they were parsed from,
+	// used for nodes that were merged in from other files.
+	nodeSources map[*yaml.Node]string
+
 	conf LintConfig
 }
 
@@ -709,6 +715,22 @@ func NewLintContext(conf LintConfig) LintContext {
 	}
 }
 
+// WithNodeSources returns a copy of the linting context where lints for the
+// provided nodes are attributed to the path they map to.
+func (ctx LintContext) WithNodeSources(sources map[*yaml.Node]string) LintContext {
+	ctx.nodeSources = sources
+	return ctx
+}
+
+// locate sets the path of a lint that does not yet have one to the source file
+// of the node it was created for.
+func (ctx LintContext) locate(node *yaml.Node, l Lint) Lint {
+	if path, exists := ctx.nodeSources[node]; exists && l.Path == "" {
+		l.Path = path
+	}
+	return l
+}
+
 // LintFunc is a common linting function for field values.
 type LintFunc func(ctx LintContext, line, col int, value any) []Lint
 
@@ -787,7 +809,8 @@ const (
 // Lint describes a single linting issue found with a Benthos config.
 type Lint struct {
 	Line   int
-	Column int // Optional, set to 1 by default
+	Column int    // Optional, set to 1 by default
+	Path   string // Optional, set when the lint originates from another file
 	Level  LintLevel
 	Type   LintType
 	What   string
@@ -808,9 +831,9 @@ func NewLintWarning(line int, t LintType, msg string) Lint {
 }
 
 // Error returns a formatted string explaining the lint error prefixed with its
-// location within the file.
+// location within the file, including the path of the file when set.
 func (l Lint) Error() string {
-	return fmt.Sprintf("(%v,%v) %v", l.Line, l.Column, l.What)
+	return fmt.Sprintf("%v(%v,%v) %v", l.Path, l.Line, l.Column, l.What)
 }
 
 //------------------------------------------------------------------------------
@@ -448,7 +448,17 @@ func (f FieldSpecs) SanitiseYAML(node *yaml.Node, conf SanitiseConfig) error {
 		}
 		value, exists := nodeKeys[field.Name]
 		if !exists {
-			continue
+			if !conf.AddDefaults || (field.IsOptional && field.Default == nil) {
+				continue
+			}
+			defValue, err := getDefault(field.Name, field)
+			if err != nil {
+				continue
+			}
+			value = &yaml.Node{}
+			if err := value.Encode(defValue); err != nil {
+				return err
+			}
 		}
 		if conf.Filter.shouldDrop(field, value) {
 			continue
@@ -471,10 +481,10 @@ func (f FieldSpecs) SanitiseYAML(node *yaml.Node, conf SanitiseConfig) error {
 
 //------------------------------------------------------------------------------
 
-func lintYAMLFromOmit(parentSpec FieldSpecs, lintTargetSpec FieldSpec, parent, node *yaml.Node) []Lint {
+func lintYAMLFromOmit(ctx LintContext, parentSpec FieldSpecs, lintTargetSpec FieldSpec, parent, node *yaml.Node) []Lint {
 	why, shouldOmit := lintTargetSpec.shouldOmitYAML(parentSpec, node, parent)
 	if shouldOmit {
-		return []Lint{NewLintError(node.Line, LintShouldOmit, errors.New(why))}
+		return []Lint{ctx.locate(node, NewLintError(node.Line, LintShouldOmit, errors.New(why)))}
 	}
 	return nil
 }
@@ -499,6 +509,9 @@ func customLintFromYAML(ctx LintContext, spec FieldSpec, node *yaml.Node) []Lint
 	}
 
 	lints := lintFn(ctx, line, node.Column, fieldValue)
+	for i := range lints {
+		lints[i] = ctx.locate(node, lints[i])
+	}
 	return lints
 }
 
@@ -529,19 +542,19 @@ func LintYAML(ctx LintContext, cType Type, node *yaml.Node) []Lint {
 			if len(keys) == 1 {
 				errMsg = "unable to infer component type: " + keys[0]
 			}
-			lints = append(lints, NewLintWarning(node.Line, LintComponentMissing, errMsg))
+			lints = append(lints, ctx.locate(node, NewLintWarning(node.Line, LintComponentMissing, errMsg)))
 			return lints
 		}
 	}
 
 	cSpec, exists := ctx.conf.DocsProvider.GetDocs(name, cType)
 	if !exists {
-		lints = append(lints, NewLintWarning(node.Line, LintComponentNotFound, fmt.Sprintf("failed to obtain docs for %v type %v", cType, name)))
+		lints = append(lints, ctx.locate(node, NewLintWarning(node.Line, LintComponentNotFound, fmt.Sprintf("failed to obtain docs for %v type %v", cType, name))))
 		return lints
 	}
 
 	if ctx.conf.RejectDeprecated && cSpec.Status == StatusDeprecated {
-		lints = append(lints, NewLintError(node.Line, LintDeprecated, fmt.Errorf("component %v is deprecated", cSpec.Name)))
+		lints = append(lints, ctx.locate(node, NewLintError(node.Line, LintDeprecated, fmt.Errorf("component %v is deprecated", cSpec.Name))))
 	}
 
 	nameFound := false
@@ -563,7 +576,7 @@ func LintYAML(ctx LintContext, cType Type, node *yaml.Node) []Lint {
 		}
 		if key == "plugin" {
 			if nameFound || !cSpec.Plugin {
-				lints = append(lints, NewLintError(node.Content[i].Line, LintShouldOmit, errors.New("plugin object is ineffective")))
+				lints = append(lints, ctx.locate(node.Content[i], NewLintError(node.Content[i].Line, LintShouldOmit, errors.New("plugin object is ineffective"))))
 			} else {
 				lints = append(lints, cSpec.Config.LintYAML(ctx, node.Content[i+1])...)
 			}
@@ -571,19 +584,19 @@ func LintYAML(ctx LintContext, cType Type, node *yaml.Node) []Lint {
 		spec, exists := reservedFields[key]
 		hasLabel = hasLabel || (key == "label")
 		if exists {
-			lints = append(lints, lintYAMLFromOmit(cSpec.Config.Children, spec, node, node.Content[i+1])...)
+			lints = append(lints, lintYAMLFromOmit(ctx, cSpec.Config.Children, spec, node, node.Content[i+1])...)
 			lints = append(lints, spec.LintYAML(ctx, node.Content[i+1])...)
 		} else {
-			lints = append(lints, NewLintError(
+			lints = append(lints, ctx.locate(node.Content[i], NewLintError(
 				node.Content[i].Line,
 				LintUnknown,
 				fmt.Errorf("field %v is invalid when the component type is %v (%v)", node.Content[i].Value, name, cType),
-			))
+			)))
 		}
 	}
 
 	if ctx.conf.RequireLabels && canLabel && !hasLabel && name != "resource" {
-		lints = append(lints, NewLintError(node.Line, LintMissingLabel, fmt.Errorf("label is required for %s", cSpec.Name)))
+		lints = append(lints, ctx.locate(node, NewLintError(node.Line, LintMissingLabel, fmt.Errorf("label is required for %s", cSpec.Name))))
 	}
 
 	return lints
@@ -597,7 +610,7 @@ func (f FieldSpec) LintYAML(ctx LintContext, node *yaml.Node) []Lint {
 	var lints []Lint
 
 	if ctx.conf.RejectDeprecated && f.IsDeprecated {
-		lints = append(lints, NewLintError(node.Line, LintDeprecated, fmt.Errorf("field %v is deprecated", f.Name)))
+		lints = append(lints, ctx.locate(node, NewLintError(node.Line, LintDeprecated, fmt.Errorf("field %v is deprecated", f.Name))))
 	}
 
 	// Execute custom linters, if the kind is non-scalar this means we execute
@@ -609,7 +622,7 @@ func (f FieldSpec) LintYAML(ctx LintContext, node *yaml.Node) []Lint {
 	switch f.Kind {
 	case Kind2DArray:
 		if node.Kind != yaml.SequenceNode {
-			lints = append(lints, NewLintError(node.Line, LintExpectedArray, errors.New("expected array value")))
+			lints = append(lints, ctx.locate(node, NewLintError(node.Line, LintExpectedArray, errors.New("expected array value"))))
 			return lints
 		}
 		for i := 0; i < len(node.Content); i++ {
@@ -618,7 +631,7 @@ func (f FieldSpec) LintYAML(ctx LintContext, node *yaml.Node) []Lint {
 		return lints
 	case KindArray:
 		if node.Kind != yaml.SequenceNode {
-			lints = append(lints, NewLintError(node.Line, LintExpectedArray, errors.New("expected array value")))
+			lints = append(lints, ctx.locate(node, NewLintError(node.Line, LintExpectedArray, errors.New("expected array value"))))
 			return lints
 		}
 		for i := 0; i < len(node.Content); i++ {
@@ -627,7 +640,7 @@ func (f FieldSpec) LintYAML(ctx LintContext, node *yaml.Node) []Lint {
 		return lints
 	case KindMap:
 		if node.Kind != yaml.MappingNode {
-			lints = append(lints, NewLintError(node.Line, LintExpectedObject, fmt.Errorf("expected object value, got %v", node.ShortTag())))
+			lints = append(lints, ctx.locate(node, NewLintError(node.Line, LintExpectedObject, fmt.Errorf("expected object value, got %v", node.ShortTag()))))
 			return lints
 		}
 		for i := 0; i < len(node.Content)-1; i += 2 {
@@ -651,11 +664,11 @@ func (f FieldSpec) LintYAML(ctx LintContext, node *yaml.Node) []Lint {
 	// TODO: Do proper checking for bool and number types.
 	case FieldTypeBool, FieldTypeString, FieldTypeInt, FieldTypeFloat:
 		if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
-			lints = append(lints, NewLintError(node.Line, LintExpectedScalar, fmt.Errorf("expected %v value", f.Type)))
+			lints = append(lints, ctx.locate(node, NewLintError(node.Line, LintExpectedScalar, fmt.Errorf("expected %v value", f.Type))))
 		}
 	case FieldTypeObject:
 		if node.Kind != yaml.MappingNode && node.Kind != yaml.AliasNode {
-			lints = append(lints, NewLintError(node.Line, LintExpectedObject, fmt.Errorf("expected object value, got %v", node.ShortTag())))
+			lints = append(lints, ctx.locate(node, NewLintError(node.Line, LintExpectedObject, fmt.Errorf("expected object value, got %v", node.ShortTag()))))
 		}
 	}
 	return lints
@@ -671,7 +684,7 @@ func (f FieldSpecs) LintYAML(ctx LintContext, node *yaml.Node) []Lint {
 			// TODO: Actually lint through aliases
 			return nil
 		}
-		lints = append(lints, NewLintError(node.Line, LintExpectedObject, fmt.Errorf("expected object value, got %v", node.ShortTag())))
+		lints = append(lints, ctx.locate(node, NewLintError(node.Line, LintExpectedObject, fmt.Errorf("expected object value, got %v", node.ShortTag()))))
 		return lints
 	}
 
@@ -691,11 +704,11 @@ func (f FieldSpecs) LintYAML(ctx LintContext, node *yaml.Node) []Lint {
 			spec, exists := specNamesAll[walkNode.Content[i].Value]
 			if !exists {
 				if walkNode.Content[i+1].Kind != yaml.AliasNode {
-					lints = append(lints, NewLintError(walkNode.Content[i].Line, LintUnknown, fmt.Errorf("field %v not recognised", walkNode.Content[i].Value)))
+					lints = append(lints, ctx.locate(walkNode.Content[i], NewLintError(walkNode.Content[i].Line, LintUnknown, fmt.Errorf("field %v not recognised", walkNode.Content[i].Value))))
 				}
 				continue
 			}
-			lints = append(lints, lintYAMLFromOmit(f, spec, walkNode, walkNode.Content[i+1])...)
+			lints = append(lints, lintYAMLFromOmit(ctx, f, spec, walkNode, walkNode.Content[i+1])...)
 			lints = append(lints, spec.LintYAML(ctx, walkNode.Content[i+1])...)
 			delete(specNamesMissing, walkNode.Content[i].Value)
 		}
@@ -709,7 +722,7 @@ func (f FieldSpecs) LintYAML(ctx LintContext, node *yaml.Node) []Lint {
 			!isCore &&
 			remaining.Kind == KindScalar &&
 			len(remaining.Children) == 0 {
-			lints = append(lints, NewLintError(node.Line, LintMissing, fmt.Errorf("field %v is required", name)))
+			lints = append(lints, ctx.locate(node, NewLintError(node.Line, LintMissing, fmt.Errorf("field %v is required", name))))
 		}
 	}
 	return lints
@@ -1103,6 +1103,44 @@ second:
 	})
 }
 
+func TestYAMLLintNodeSources(t *testing.T) {
+	prov := docs.NewMappedDocsProvider()
+	prov.RegisterDocs(docs.ComponentSpec{
+		Name: "meowthing",
+		Type: docs.TypeInput,
+		Config: docs.FieldComponent().WithChildren(
+			docs.FieldString("foo", ""),
+		),
+	})
+
+	lConf := docs.NewLintConfig(bundle.GlobalEnvironment)
+	lConf.DocsProvider = prov
+
+	var mainNode, incNode yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`
+meowthing:
+  foo: one
+  bar: two
+`), &mainNode))
+	require.NoError(t, yaml.Unmarshal([]byte(`
+baz: three
+`), &incNode))
+
+	// Merge a field from another file into the component.
+	root := mainNode.Content[0]
+	root.Content = append(root.Content, incNode.Content[0].Content...)
+
+	lintCtx := docs.NewLintContext(lConf).WithNodeSources(map[*yaml.Node]string{
+		incNode.Content[0].Content[0]: "inc.yaml",
+		incNode.Content[0].Content[1]: "inc.yaml",
+	})
+	lints := docs.FieldInput("root", "").LintYAML(lintCtx, &mainNode)
+	assert.Equal(t, []docs.Lint{
+		{Line: 4, Column: 1, Level: docs.LintError, Type: docs.LintUnknown, What: "field bar not recognised"},
+		{Line: 2, Column: 1, Path: "inc.yaml", Level: docs.LintError, Type: docs.LintUnknown, What: "field baz is invalid when the component type is meowthing (input)"},
+	}, lints)
+}
+
 func TestYAMLLinting(t *testing.T) {
 	type testCase struct {
 		name      string
@@ -19,6 +19,20 @@ type FS interface {
 	MkdirAll(path string, perm fs.FileMode) error
 }
 
+// Renamer is implemented by filesystems that support moving files.
+type Renamer interface {
+	Rename(oldpath, newpath string) error
+}
+
+// Rename moves a file from oldpath to newpath, replacing newpath if it already
+// exists. An error is returned if the filesystem does not support renames.
+func Rename(f fs.FS, oldpath, newpath string) error {
+	if r, ok := f.(Renamer); ok {
+		return r.Rename(oldpath, newpath)
+	}
+	return errors.ErrUnsupported
+}
+
 // ReadFile opens a file with the RDONLY flag and returns all bytes from it.
 func ReadFile(f fs.FS, name string) ([]byte, error) {
 	var i fs.File
@@ -93,3 +107,7 @@ func (o *osPT) Remove(name string) error {
 func (o *osPT) MkdirAll(path string, perm fs.FileMode) error {
 	return os.MkdirAll(path, perm)
 }
+
+func (o *osPT) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
@@ -3,6 +3,8 @@ package ifs
 import (
 	"errors"
 	"io/fs"
+	"os"
+	"path/filepath"
 	"testing"
 	"testing/fstest"
 
@@ -34,3 +36,20 @@ func TestOSAccess(t *testing.T) {
 
 	require.True(t, IsOS(fs))
 }
+
+func TestRename(t *testing.T) {
+	require.ErrorIs(t, Rename(testFS{}, "a", "b"), errors.ErrUnsupported)
+
+	dir := t.TempDir()
+	oldPath, newPath := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	require.NoError(t, os.WriteFile(oldPath, []byte("hello"), 0o644))
+
+	require.NoError(t, Rename(OS(), oldPath, newPath))
+
+	_, err := os.Stat(oldPath)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	b, err := os.ReadFile(newPath)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(b))
+}
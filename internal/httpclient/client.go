@@ -194,6 +194,22 @@ func (h *Client) waitForAccess(ctx context.Context) bool {
 	}
 }
 
+// rateLimitFeedback reports the outcome of a request to the configured rate
+// limit, if it is able to receive it.
+func (h *Client) rateLimitFeedback(ctx context.Context, latency time.Duration, res *http.Response, err error) {
+	if h.rateLimit == "" || ctx.Err() != nil {
+		return
+	}
+	throttled := err != nil || res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+	if rerr := h.mgr.AccessRateLimit(ctx, h.rateLimit, func(rl service.RateLimit) {
+		if f, ok := rl.(service.RateLimitFeedbackReceiver); ok {
+			f.Feedback(ctx, latency, throttled)
+		}
+	}); rerr != nil {
+		h.log.Errorf("Rate limit error: %v\n", rerr)
+	}
+}
+
 // ResponseToBatch attempts to parse an HTTP response into a 2D slice of bytes.
 func (h *Client) ResponseToBatch(res *http.Response) (service.MessageBatch, error) {
 	var resMsg service.MessageBatch
@@ -346,7 +362,9 @@ func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatc
 	numRetries := h.numRetries
 
 	startedAt := time.Now()
-	if res, err = h.client.Do(req.WithContext(ctx)); err == nil {
+	res, err = h.client.Do(req.WithContext(ctx))
+	h.rateLimitFeedback(ctx, time.Since(startedAt), res, err)
+	if err == nil {
 		h.incrCode(res.StatusCode)
 		if resolved, retryStrat := h.checkStatus(res.StatusCode); !resolved {
 			rateLimited = retryStrat == retryBackoff
@@ -391,7 +409,9 @@ func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatc
 		rateLimited = false
 
 		startedAt = time.Now()
-		if res, err = h.client.Do(req.WithContext(ctx)); err == nil {
+		res, err = h.client.Do(req.WithContext(ctx))
+		h.rateLimitFeedback(ctx, time.Since(startedAt), res, err)
+		if err == nil {
 			h.incrCode(res.StatusCode)
 			if resolved, retryStrat := h.checkStatus(res.StatusCode); !resolved {
 				rateLimited = retryStrat == retryBackoff
@@ -3,6 +3,7 @@ package httpserver
 import (
 	"errors"
 	"net/http"
+	"slices"
 
 	"github.com/gorilla/handlers"
 
@@ -13,19 +14,30 @@ const (
 	fieldCORS               = "cors"
 	fieldCORSEnabled        = "enabled"
 	fieldCORSAllowedOrigins = "allowed_origins"
+	fieldCORSAllowedMethods = "allowed_methods"
+	fieldCORSAllowedHeaders = "allowed_headers"
+	fieldCORSAllowCreds     = "allow_credentials"
 )
 
+var defaultCORSAllowedMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}
+
 // CORSConfig contains struct configuration for allowing CORS headers.
 type CORSConfig struct {
-	Enabled        bool     `json:"enabled" yaml:"enabled"`
-	AllowedOrigins []string `json:"allowed_origins" yaml:"allowed_origins"`
+	Enabled          bool     `json:"enabled" yaml:"enabled"`
+	AllowedOrigins   []string `json:"allowed_origins" yaml:"allowed_origins"`
+	AllowedMethods   []string `json:"allowed_methods" yaml:"allowed_methods"`
+	AllowedHeaders   []string `json:"allowed_headers" yaml:"allowed_headers"`
+	AllowCredentials bool     `json:"allow_credentials" yaml:"allow_credentials"`
 }
 
 // NewServerCORSConfig returns a new server CORS config with default fields.
 func NewServerCORSConfig() CORSConfig {
 	return CORSConfig{
-		Enabled:        false,
-		AllowedOrigins: []string{},
+		Enabled:          false,
+		AllowedOrigins:   []string{},
+		AllowedMethods:   append([]string{}, defaultCORSAllowedMethods...),
+		AllowedHeaders:   []string{},
+		AllowCredentials: false,
 	}
 }
 
@@ -38,10 +50,25 @@ func (conf CORSConfig) WrapHandler(handler http.Handler) (http.Handler, error) {
 	if len(conf.AllowedOrigins) == 0 {
 		return nil, errors.New("must specify at least one allowed origin")
 	}
-	return handlers.CORS(
+	if conf.AllowCredentials && slices.Contains(conf.AllowedOrigins, "*") {
+		return nil, errors.New("credentials cannot be allowed with a wildcard origin")
+	}
+
+	methods := conf.AllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSAllowedMethods
+	}
+	opts := []handlers.CORSOption{
 		handlers.AllowedOrigins(conf.AllowedOrigins),
-		handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}),
-	)(handler), nil
+		handlers.AllowedMethods(methods),
+	}
+	if len(conf.AllowedHeaders) > 0 {
+		opts = append(opts, handlers.AllowedHeaders(conf.AllowedHeaders))
+	}
+	if conf.AllowCredentials {
+		opts = append(opts, handlers.AllowCredentials())
+	}
+	return handlers.CORS(opts...)(handler), nil
 }
 
 // ServerCORSFieldSpec returns a field spec for an http server CORS component.
@@ -49,6 +76,9 @@ func ServerCORSFieldSpec() docs.FieldSpec {
 	return docs.FieldObject(fieldCORS, "Adds Cross-Origin Resource Sharing headers.").WithChildren(
 		docs.FieldBool(fieldCORSEnabled, "Whether to allow CORS requests.").HasDefault(false),
 		docs.FieldString(fieldCORSAllowedOrigins, "An explicit list of origins that are allowed for CORS requests.").Array().HasDefault([]any{}),
+		docs.FieldString(fieldCORSAllowedMethods, "A list of HTTP methods that are allowed for CORS requests.").Array().HasDefault([]any{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}).AtVersion("4.39.0"),
+		docs.FieldString(fieldCORSAllowedHeaders, "A list of request headers that are allowed for CORS requests in addition to the simple headers `Accept`, `Accept-Language`, `Content-Language` and `Origin`.").Array().HasDefault([]any{}).AtVersion("4.39.0"),
+		docs.FieldBool(fieldCORSAllowCreds, "Whether to allow CORS requests to include credentials such as cookies and authorization headers. Cannot be used with a wildcard origin.").HasDefault(false).AtVersion("4.39.0"),
 	).AtVersion("3.63.0").Advanced()
 }
 
@@ -61,5 +91,20 @@ func CORSConfigFromParsed(pConf *docs.ParsedConfig) (conf CORSConfig, err error)
 	if conf.AllowedOrigins, err = pConf.FieldStringList(fieldCORSAllowedOrigins); err != nil {
 		return
 	}
+	if pConf.Contains(fieldCORSAllowedMethods) {
+		if conf.AllowedMethods, err = pConf.FieldStringList(fieldCORSAllowedMethods); err != nil {
+			return
+		}
+	}
+	if pConf.Contains(fieldCORSAllowedHeaders) {
+		if conf.AllowedHeaders, err = pConf.FieldStringList(fieldCORSAllowedHeaders); err != nil {
+			return
+		}
+	}
+	if pConf.Contains(fieldCORSAllowCreds) {
+		if conf.AllowCredentials, err = pConf.FieldBool(fieldCORSAllowCreds); err != nil {
+			return
+		}
+	}
 	return
 }
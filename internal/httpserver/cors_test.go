@@ -85,3 +85,53 @@ func TestAPIEnableCORSNoHeaders(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "must specify at least one allowed origin")
 }
+
+func TestAPIEnableCORSMethodsHeadersCredentials(t *testing.T) {
+	conf := NewServerCORSConfig()
+	conf.Enabled = true
+	conf.AllowedOrigins = []string{"foo"}
+	conf.AllowedMethods = []string{"POST"}
+	conf.AllowedHeaders = []string{"X-Api-Key"}
+	conf.AllowCredentials = true
+
+	tmpHandler := http.NewServeMux()
+	tmpHandler.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("1.2.3"))
+	})
+
+	handler, err := conf.WrapHandler(tmpHandler)
+	require.NoError(t, err)
+
+	request, _ := http.NewRequest("OPTIONS", "/version", http.NoBody)
+	request.Header.Add("Origin", "foo")
+	request.Header.Add("Access-Control-Request-Method", "POST")
+	request.Header.Add("Access-Control-Request-Headers", "X-Api-Key")
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "foo", response.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", response.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "X-Api-Key", response.Header().Get("Access-Control-Allow-Headers"))
+
+	request, _ = http.NewRequest("OPTIONS", "/version", http.NoBody)
+	request.Header.Add("Origin", "foo")
+	request.Header.Add("Access-Control-Request-Method", "DELETE")
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+}
+
+func TestAPIEnableCORSWildcardCredentials(t *testing.T) {
+	conf := NewServerCORSConfig()
+	conf.Enabled = true
+	conf.AllowedOrigins = []string{"*"}
+	conf.AllowCredentials = true
+
+	_, err := conf.WrapHandler(http.NewServeMux())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "credentials cannot be allowed with a wildcard origin")
+}
@@ -0,0 +1,644 @@
+package io
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/redpanda-data/benthos/v4/internal/component"
+	"github.com/redpanda-data/benthos/v4/internal/filepath/ifs"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	dbFieldPath        = "path"
+	dbFieldCompression = "compression"
+	dbFieldLimit       = "limit"
+	dbFieldOnFull      = "on_full"
+
+	diskBufferFileExt = ".batch"
+	diskBufferTmpExt  = ".tmp"
+)
+
+func diskBufferConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.39.0").
+		Categories("Utility").
+		Summary("Stores consumed message batches as files within a directory and acknowledges them at the input level once they have been persisted.").
+		Description(`
+Each batch written to this buffer is stored as an individual file within the configured directory and synced to disk before it is acknowledged, batches are then removed once they have been read and acknowledged downstream. Batches that remain on disk when the service is shut down are read again when the buffer is next started with the same path.
+
+Message contents and metadata are both persisted, but structured metadata values are stored in their string form.
+
+== Size limit
+
+The total size of the batch files on disk is capped by the field ` + "`limit`" + `. When writing a batch would exceed this limit the buffer either applies back pressure upstream until space is freed, or rejects the batch so that it is nacked at the input level, depending on the field ` + "`on_full`" + `.
+
+== Metrics
+
+This buffer emits the gauges ` + "`buffer_disk_bytes`" + ` and ` + "`buffer_disk_batches`" + ` tracking the size and number of batches currently stored, and ` + "`buffer_disk_oldest_age_ns`" + ` tracking the age of the oldest stored batch in nanoseconds.
+
+== Delivery guarantees
+
+Batches are only removed from disk once they have been delivered, and are therefore delivered at least once as long as the directory is preserved. Batches that were being delivered during an unclean shut down are delivered again when the buffer is restarted.`).
+		Field(service.NewStringField(dbFieldPath).
+			Description("The directory within which to store batches, which is created if it does not already exist.").
+			Example("/var/lib/benthos/buffer")).
+		Field(service.NewStringEnumField(dbFieldCompression, "none", "zstd").
+			Description("The compression algorithm to apply to batches stored on disk.").
+			Default("none")).
+		Field(service.NewIntField(dbFieldLimit).
+			Description("The maximum total size of batches stored on disk, in bytes.").
+			Default(1073741824)).
+		Field(service.NewStringAnnotatedEnumField(dbFieldOnFull, map[string]string{
+			"block":  "Apply back pressure upstream until enough space has been freed.",
+			"reject": "Reject the batch so that it is nacked by the input.",
+		}).
+			Description("The behaviour of the buffer when writing a batch would exceed the `limit`.").
+			Default("block"))
+}
+
+func init() {
+	err := service.RegisterBatchBuffer(
+		"disk", diskBufferConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchBuffer, error) {
+			return newDiskBufferFromConfig(conf, mgr)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func newDiskBufferFromConfig(conf *service.ParsedConfig, mgr *service.Resources) (*diskBuffer, error) {
+	path, err := conf.FieldString(dbFieldPath)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := conf.FieldString(dbFieldCompression)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := conf.FieldInt(dbFieldLimit)
+	if err != nil {
+		return nil, err
+	}
+	onFull, err := conf.FieldString(dbFieldOnFull)
+	if err != nil {
+		return nil, err
+	}
+	return newDiskBuffer(path, compression == "zstd", int64(limit), onFull == "reject", mgr)
+}
+
+//------------------------------------------------------------------------------
+
+// errDiskBufferFull is returned when a batch is rejected because writing it
+// would exceed the limit of the disk buffer.
+var errDiskBufferFull = errors.New("disk buffer is full")
+
+const (
+	diskBatchRaw byte = iota
+	diskBatchZstd
+)
+
+type diskBatch struct {
+	seq       uint64
+	size      int64
+	writtenAt time.Time
+}
+
+type diskBuffer struct {
+	fs       *service.FS
+	dir      string
+	compress bool
+	limit    int64
+	reject   bool
+
+	// The codecs are used outside of the main lock and therefore are guarded
+	// separately so that they are only closed once no longer in use.
+	codecMut     sync.RWMutex
+	codecsClosed bool
+	encoder      *zstd.Encoder
+	decoder      *zstd.Decoder
+
+	log        *service.Logger
+	mBytes     *service.MetricGauge
+	mBatches   *service.MetricGauge
+	mOldestAge *service.MetricGauge
+
+	cond       *sync.Cond
+	pending    []diskBatch
+	inFlight   map[uint64]diskBatch
+	bytes      int64
+	nextSeq    uint64
+	endOfInput bool
+	closed     bool
+
+	closeChan chan struct{}
+}
+
+func newDiskBuffer(dir string, compress bool, limit int64, reject bool, mgr *service.Resources) (*diskBuffer, error) {
+	if limit <= 0 {
+		return nil, errors.New("limit must be larger than zero")
+	}
+	if err := mgr.FS().MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	d := &diskBuffer{
+		fs:       mgr.FS(),
+		dir:      dir,
+		compress: compress,
+		limit:    limit,
+		reject:   reject,
+
+		log:        mgr.Logger(),
+		mBytes:     mgr.Metrics().NewGauge("buffer_disk_bytes"),
+		mBatches:   mgr.Metrics().NewGauge("buffer_disk_batches"),
+		mOldestAge: mgr.Metrics().NewGauge("buffer_disk_oldest_age_ns"),
+
+		cond:      sync.NewCond(&sync.Mutex{}),
+		inFlight:  map[uint64]diskBatch{},
+		closeChan: make(chan struct{}),
+	}
+
+	var err error
+	if d.encoder, err = zstd.NewWriter(nil); err != nil {
+		return nil, err
+	}
+	if d.decoder, err = zstd.NewReader(nil); err != nil {
+		return nil, err
+	}
+	if err := d.loadExisting(); err != nil {
+		return nil, err
+	}
+	d.updateMetrics()
+
+	go d.loopMetrics()
+	return d, nil
+}
+
+// loadExisting populates the pending batches from files left over from a
+// previous run, and removes any temporary files of batches that were not fully
+// written.
+func (d *diskBuffer) loadExisting() error {
+	entries, err := fs.ReadDir(d.fs, d.dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() {
+			continue
+		}
+		if strings.HasSuffix(name, diskBufferFileExt+diskBufferTmpExt) {
+			if err := d.fs.Remove(filepath.Join(d.dir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			continue
+		}
+		if !strings.HasSuffix(name, diskBufferFileExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, diskBufferFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		d.pending = append(d.pending, diskBatch{
+			seq:       seq,
+			size:      info.Size(),
+			writtenAt: info.ModTime(),
+		})
+		d.bytes += info.Size()
+		if seq >= d.nextSeq {
+			d.nextSeq = seq + 1
+		}
+	}
+	sort.Slice(d.pending, func(i, j int) bool {
+		return d.pending[i].seq < d.pending[j].seq
+	})
+	return nil
+}
+
+func (d *diskBuffer) batchPath(seq uint64) string {
+	return filepath.Join(d.dir, fmt.Sprintf("%020d%v", seq, diskBufferFileExt))
+}
+
+// updateMetrics must be called while holding the mutex.
+func (d *diskBuffer) updateMetrics() {
+	d.mBytes.Set(d.bytes)
+	d.mBatches.Set(int64(len(d.pending) + len(d.inFlight)))
+
+	var oldest time.Time
+	if len(d.pending) > 0 {
+		oldest = d.pending[0].writtenAt
+	}
+	for _, b := range d.inFlight {
+		if oldest.IsZero() || b.writtenAt.Before(oldest) {
+			oldest = b.writtenAt
+		}
+	}
+	if oldest.IsZero() {
+		d.mOldestAge.Set(0)
+	} else {
+		d.mOldestAge.Set(time.Since(oldest).Nanoseconds())
+	}
+}
+
+func (d *diskBuffer) loopMetrics() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			d.cond.L.Lock()
+			d.updateMetrics()
+			d.cond.L.Unlock()
+		case <-d.closeChan:
+			return
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func writeDiskBufferBytes(buf *bytes.Buffer, b []byte) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(b)))
+	_, _ = buf.Write(b)
+}
+
+func readDiskBufferBytes(r *bytes.Reader) ([]byte, error) {
+	var l uint32
+	if err := binary.Read(r, binary.BigEndian, &l); err != nil {
+		return nil, err
+	}
+	// Guard against allocating based on a corrupted length.
+	if int64(l) > int64(r.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := make([]byte, l)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (d *diskBuffer) encodeBatch(batch service.MessageBatch) ([]byte, error) {
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.BigEndian, uint32(len(batch)))
+	for _, msg := range batch {
+		var keys, values []string
+		_ = msg.MetaWalk(func(k, v string) error {
+			keys = append(keys, k)
+			values = append(values, v)
+			return nil
+		})
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(keys)))
+		for i, k := range keys {
+			writeDiskBufferBytes(&buf, []byte(k))
+			writeDiskBufferBytes(&buf, []byte(values[i]))
+		}
+
+		mBytes, err := msg.AsBytes()
+		if err != nil {
+			return nil, err
+		}
+		writeDiskBufferBytes(&buf, mBytes)
+	}
+
+	if d.compress {
+		d.codecMut.RLock()
+		defer d.codecMut.RUnlock()
+		if d.codecsClosed {
+			return nil, component.ErrTypeClosed
+		}
+		return append([]byte{diskBatchZstd}, d.encoder.EncodeAll(buf.Bytes(), nil)...), nil
+	}
+	return append([]byte{diskBatchRaw}, buf.Bytes()...), nil
+}
+
+func (d *diskBuffer) decompress(b []byte) ([]byte, error) {
+	d.codecMut.RLock()
+	defer d.codecMut.RUnlock()
+	if d.codecsClosed {
+		return nil, component.ErrTypeClosed
+	}
+	return d.decoder.DecodeAll(b, nil)
+}
+
+func (d *diskBuffer) decodeBatch(b []byte) (service.MessageBatch, error) {
+	if len(b) == 0 {
+		return nil, errors.New("empty batch file")
+	}
+
+	payload := b[1:]
+	switch b[0] {
+	case diskBatchRaw:
+	case diskBatchZstd:
+		var err error
+		if payload, err = d.decompress(payload); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unrecognised batch encoding: %v", b[0])
+	}
+
+	r := bytes.NewReader(payload)
+
+	var nMsgs uint32
+	if err := binary.Read(r, binary.BigEndian, &nMsgs); err != nil {
+		return nil, err
+	}
+	// Each message occupies at least eight bytes, which bounds the capacity
+	// allocated for a corrupted count.
+	batch := make(service.MessageBatch, 0, min(int(nMsgs), r.Len()/8))
+	for i := uint32(0); i < nMsgs; i++ {
+		var nMeta uint32
+		if err := binary.Read(r, binary.BigEndian, &nMeta); err != nil {
+			return nil, err
+		}
+		meta := make([][2]string, 0, min(int(nMeta), r.Len()/8))
+		for j := uint32(0); j < nMeta; j++ {
+			k, err := readDiskBufferBytes(r)
+			if err != nil {
+				return nil, err
+			}
+			v, err := readDiskBufferBytes(r)
+			if err != nil {
+				return nil, err
+			}
+			meta = append(meta, [2]string{string(k), string(v)})
+		}
+		content, err := readDiskBufferBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		msg := service.NewMessage(content)
+		for _, kv := range meta {
+			msg.MetaSetMut(kv[0], kv[1])
+		}
+		batch = append(batch, msg)
+	}
+	return batch, nil
+}
+
+//------------------------------------------------------------------------------
+
+// insertPending adds a batch to the pending queue whilst preserving the order
+// of batches. Must be called while holding the mutex.
+func (d *diskBuffer) insertPending(b diskBatch) {
+	i := sort.Search(len(d.pending), func(i int) bool {
+		return d.pending[i].seq > b.seq
+	})
+	d.pending = append(d.pending[:i], append([]diskBatch{b}, d.pending[i:]...)...)
+}
+
+// waitOnCtx broadcasts on the condition once the provided context is done so
+// that waiting calls are able to observe it. The returned func must be called
+// in order to release resources.
+func (d *diskBuffer) waitOnCtx(ctx context.Context) (context.Context, func()) {
+	ctx, done := context.WithCancel(ctx)
+	go func() {
+		<-ctx.Done()
+		d.cond.L.Lock()
+		d.cond.Broadcast()
+		d.cond.L.Unlock()
+	}()
+	return ctx, done
+}
+
+func (d *diskBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	ctx, done := d.waitOnCtx(ctx)
+	defer done()
+
+	d.cond.L.Lock()
+	for {
+		if d.closed {
+			d.cond.L.Unlock()
+			return nil, nil, service.ErrEndOfBuffer
+		}
+		if ctx.Err() != nil {
+			d.cond.L.Unlock()
+			return nil, nil, ctx.Err()
+		}
+		if len(d.pending) > 0 {
+			break
+		}
+		if d.endOfInput && len(d.inFlight) == 0 {
+			d.cond.L.Unlock()
+			return nil, nil, service.ErrEndOfBuffer
+		}
+		d.cond.Wait()
+	}
+
+	// The batch is marked as in flight whilst it is read so that the buffer
+	// isn't considered drained, but the lock isn't held during disk I/O.
+	b := d.pending[0]
+	d.pending = d.pending[1:]
+	d.inFlight[b.seq] = b
+	d.cond.L.Unlock()
+
+	fileBytes, err := ifs.ReadFile(d.fs, d.batchPath(b.seq))
+	if err != nil {
+		d.cond.L.Lock()
+		delete(d.inFlight, b.seq)
+		d.insertPending(b)
+		d.cond.Broadcast()
+		d.cond.L.Unlock()
+		return nil, nil, err
+	}
+
+	batch, err := d.decodeBatch(fileBytes)
+	if errors.Is(err, component.ErrTypeClosed) {
+		// The buffer was closed during the read, the batch is intact and will
+		// be read again after a restart.
+		d.cond.L.Lock()
+		delete(d.inFlight, b.seq)
+		d.insertPending(b)
+		d.cond.Broadcast()
+		d.cond.L.Unlock()
+		return nil, nil, service.ErrEndOfBuffer
+	}
+	if err != nil {
+		// A corrupted batch can never be delivered, therefore we remove it
+		// rather than attempting to read it indefinitely.
+		d.log.Errorf("Removing unreadable batch file %v: %v", d.batchPath(b.seq), err)
+		d.removeBatch(b)
+		return nil, nil, err
+	}
+
+	return batch, func(ctx context.Context, err error) error {
+		if err == nil {
+			d.removeBatch(b)
+			return nil
+		}
+
+		// Return the batch to the front of the queue whilst preserving the
+		// order of any other returned batches.
+		d.cond.L.Lock()
+		delete(d.inFlight, b.seq)
+		d.insertPending(b)
+		d.cond.Broadcast()
+		d.cond.L.Unlock()
+		return nil
+	}, nil
+}
+
+// removeBatch deletes the file of an in flight batch and releases the space it
+// occupied.
+func (d *diskBuffer) removeBatch(b diskBatch) {
+	if err := d.fs.Remove(d.batchPath(b.seq)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		d.log.Errorf("Failed to remove batch file %v: %v", d.batchPath(b.seq), err)
+	}
+
+	d.cond.L.Lock()
+	delete(d.inFlight, b.seq)
+	d.bytes -= b.size
+	d.updateMetrics()
+	d.cond.Broadcast()
+	d.cond.L.Unlock()
+}
+
+// writeBatchFile writes a batch to a temporary file which is synced and then
+// renamed into place, followed by a sync of the directory so that the rename
+// itself is persisted.
+func (d *diskBuffer) writeBatchFile(seq uint64, data []byte) error {
+	tmpPath := d.batchPath(seq) + diskBufferTmpExt
+
+	f, err := d.fs.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err = ifs.FileWrite(f, data); err == nil {
+		err = syncFile(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = d.fs.Rename(tmpPath, d.batchPath(seq))
+	}
+	if err != nil {
+		_ = d.fs.Remove(tmpPath)
+		return err
+	}
+	if err = d.syncDir(); err != nil {
+		// The write is rejected and therefore will be retried, so the batch
+		// must not be loaded again on a restart.
+		_ = d.fs.Remove(d.batchPath(seq))
+	}
+	return err
+}
+
+func (d *diskBuffer) syncDir() error {
+	dir, err := d.fs.Open(d.dir)
+	if err != nil {
+		return err
+	}
+	err = syncFile(dir)
+	if cerr := dir.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (d *diskBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageBatch, aFn service.AckFunc) error {
+	data, err := d.encodeBatch(msgBatch)
+	if err != nil {
+		return err
+	}
+
+	size := int64(len(data))
+	if size > d.limit {
+		return component.ErrMessageTooLarge
+	}
+
+	ctx, done := d.waitOnCtx(ctx)
+	defer done()
+
+	d.cond.L.Lock()
+	for !d.closed && (d.bytes+size) > d.limit {
+		if d.reject {
+			d.cond.L.Unlock()
+			return errDiskBufferFull
+		}
+		if ctx.Err() != nil {
+			d.cond.L.Unlock()
+			return ctx.Err()
+		}
+		d.cond.Wait()
+	}
+	if d.closed {
+		d.cond.L.Unlock()
+		return component.ErrTypeClosed
+	}
+
+	// Reserve the space and sequence before writing so that the lock isn't
+	// held during disk I/O.
+	seq := d.nextSeq
+	d.nextSeq++
+	d.bytes += size
+	d.cond.L.Unlock()
+
+	if err := d.writeBatchFile(seq, data); err != nil {
+		d.cond.L.Lock()
+		d.bytes -= size
+		d.cond.Broadcast()
+		d.cond.L.Unlock()
+		return err
+	}
+
+	d.cond.L.Lock()
+	d.insertPending(diskBatch{seq: seq, size: size, writtenAt: time.Now()})
+	d.updateMetrics()
+	d.cond.Broadcast()
+	d.cond.L.Unlock()
+
+	return aFn(ctx, nil)
+}
+
+func (d *diskBuffer) EndOfInput() {
+	d.cond.L.Lock()
+	d.endOfInput = true
+	d.cond.Broadcast()
+	d.cond.L.Unlock()
+}
+
+func (d *diskBuffer) Close(ctx context.Context) error {
+	d.cond.L.Lock()
+	closing := !d.closed
+	if closing {
+		d.closed = true
+		close(d.closeChan)
+	}
+	d.cond.Broadcast()
+	d.cond.L.Unlock()
+
+	if closing {
+		// Blocks until any reads or writes using the codecs have finished.
+		d.codecMut.Lock()
+		d.codecsClosed = true
+		d.decoder.Close()
+		err := d.encoder.Close()
+		d.codecMut.Unlock()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,310 @@
+package io
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/internal/component"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func newTestDiskBuffer(t *testing.T, confStr string, args ...any) *diskBuffer {
+	t.Helper()
+
+	pConf, err := diskBufferConfig().ParseYAML(fmt.Sprintf(confStr, args...), nil)
+	require.NoError(t, err)
+
+	d, err := newDiskBufferFromConfig(pConf, service.MockResources())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = d.Close(context.Background())
+	})
+	return d
+}
+
+func noopAckFn(context.Context, error) error {
+	return nil
+}
+
+func TestDiskBufferRoundTrip(t *testing.T) {
+	for _, compression := range []string{"none", "zstd"} {
+		compression := compression
+		t.Run(compression, func(t *testing.T) {
+			tCtx, done := context.WithTimeout(context.Background(), time.Second*10)
+			defer done()
+
+			d := newTestDiskBuffer(t, `
+path: %v
+compression: %v
+`, t.TempDir(), compression)
+
+			msgA := service.NewMessage([]byte("hello"))
+			msgA.MetaSetMut("foo", "bar")
+			msgB := service.NewMessage([]byte("world"))
+
+			var acked bool
+			require.NoError(t, d.WriteBatch(tCtx, service.MessageBatch{msgA, msgB}, func(context.Context, error) error {
+				acked = true
+				return nil
+			}))
+			assert.True(t, acked)
+
+			batch, ackFn, err := d.ReadBatch(tCtx)
+			require.NoError(t, err)
+			require.Len(t, batch, 2)
+
+			b, err := batch[0].AsBytes()
+			require.NoError(t, err)
+			assert.Equal(t, "hello", string(b))
+
+			v, _ := batch[0].MetaGet("foo")
+			assert.Equal(t, "bar", v)
+
+			b, err = batch[1].AsBytes()
+			require.NoError(t, err)
+			assert.Equal(t, "world", string(b))
+
+			require.NoError(t, ackFn(tCtx, nil))
+
+			d.EndOfInput()
+			_, _, err = d.ReadBatch(tCtx)
+			assert.Equal(t, service.ErrEndOfBuffer, err)
+		})
+	}
+}
+
+func TestDiskBufferNackAndRestart(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	dir := t.TempDir()
+	d := newTestDiskBuffer(t, `path: %v`, dir)
+
+	for _, content := range []string{"first", "second", "third"} {
+		require.NoError(t, d.WriteBatch(tCtx, service.MessageBatch{
+			service.NewMessage([]byte(content)),
+		}, noopAckFn))
+	}
+
+	readContent := func(d *diskBuffer) (string, service.AckFunc) {
+		t.Helper()
+		batch, ackFn, err := d.ReadBatch(tCtx)
+		require.NoError(t, err)
+		require.Len(t, batch, 1)
+		b, err := batch[0].AsBytes()
+		require.NoError(t, err)
+		return string(b), ackFn
+	}
+
+	content, firstAckFn := readContent(d)
+	assert.Equal(t, "first", content)
+
+	content, secondAckFn := readContent(d)
+	assert.Equal(t, "second", content)
+
+	require.NoError(t, secondAckFn(tCtx, nil))
+	require.NoError(t, firstAckFn(tCtx, component.ErrFailedSend))
+
+	content, _ = readContent(d)
+	assert.Equal(t, "first", content)
+
+	// Unacknowledged batches remain on disk and are read again by a new
+	// buffer using the same path.
+	require.NoError(t, d.Close(tCtx))
+
+	d = newTestDiskBuffer(t, `path: %v`, dir)
+
+	content, ackFn := readContent(d)
+	assert.Equal(t, "first", content)
+	require.NoError(t, ackFn(tCtx, nil))
+
+	content, ackFn = readContent(d)
+	assert.Equal(t, "third", content)
+	require.NoError(t, ackFn(tCtx, nil))
+
+	require.NoError(t, d.WriteBatch(tCtx, service.MessageBatch{
+		service.NewMessage([]byte("fourth")),
+	}, noopAckFn))
+
+	content, ackFn = readContent(d)
+	assert.Equal(t, "fourth", content)
+	require.NoError(t, ackFn(tCtx, nil))
+}
+
+func TestDiskBufferLimit(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	writeMsg := func(d *diskBuffer, content string) error {
+		return d.WriteBatch(tCtx, service.MessageBatch{
+			service.NewMessage([]byte(content)),
+		}, noopAckFn)
+	}
+
+	// Each batch of a single five byte message without metadata encodes to
+	// 18 bytes.
+	d := newTestDiskBuffer(t, `
+path: %v
+limit: 40
+on_full: reject
+`, t.TempDir())
+
+	require.NoError(t, writeMsg(d, "aaaaa"))
+	require.NoError(t, writeMsg(d, "bbbbb"))
+	assert.Equal(t, errDiskBufferFull, writeMsg(d, "ccccc"))
+	assert.Equal(t, component.ErrMessageTooLarge, writeMsg(d, string(make([]byte, 50))))
+
+	d = newTestDiskBuffer(t, `
+path: %v
+limit: 40
+`, t.TempDir())
+
+	require.NoError(t, writeMsg(d, "aaaaa"))
+	require.NoError(t, writeMsg(d, "bbbbb"))
+
+	writeErr := make(chan error, 1)
+	go func() {
+		writeErr <- writeMsg(d, "ccccc")
+	}()
+
+	select {
+	case err := <-writeErr:
+		t.Fatalf("Expected write to block, got: %v", err)
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	_, ackFn, err := d.ReadBatch(tCtx)
+	require.NoError(t, err)
+	require.NoError(t, ackFn(tCtx, nil))
+
+	select {
+	case err := <-writeErr:
+		require.NoError(t, err)
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+}
+
+func TestDiskBufferBlockedWriteCancelled(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	d := newTestDiskBuffer(t, `
+path: %v
+limit: 20
+`, t.TempDir())
+
+	require.NoError(t, d.WriteBatch(tCtx, service.MessageBatch{
+		service.NewMessage([]byte("aaaaa")),
+	}, noopAckFn))
+
+	wCtx, wDone := context.WithCancel(tCtx)
+	writeErr := make(chan error, 1)
+	go func() {
+		writeErr <- d.WriteBatch(wCtx, service.MessageBatch{
+			service.NewMessage([]byte("bbbbb")),
+		}, noopAckFn)
+	}()
+
+	select {
+	case err := <-writeErr:
+		t.Fatalf("Expected write to block, got: %v", err)
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	wDone()
+
+	select {
+	case err := <-writeErr:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+}
+
+func TestDiskBufferRemovesTmpFiles(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	dir := t.TempDir()
+	tmpPath := filepath.Join(dir, "00000000000000000005"+diskBufferFileExt+diskBufferTmpExt)
+	require.NoError(t, os.WriteFile(tmpPath, []byte("partial"), 0o644))
+
+	d := newTestDiskBuffer(t, `path: %v`, dir)
+
+	_, err := os.Stat(tmpPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, d.WriteBatch(tCtx, service.MessageBatch{
+		service.NewMessage([]byte("hello")),
+	}, noopAckFn))
+
+	batch, ackFn, err := d.ReadBatch(tCtx)
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	require.NoError(t, ackFn(tCtx, nil))
+}
+
+func TestDiskBufferClosedDuringRead(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	dir := t.TempDir()
+	d := newTestDiskBuffer(t, `
+path: %v
+compression: zstd
+`, dir)
+
+	require.NoError(t, d.WriteBatch(tCtx, service.MessageBatch{
+		service.NewMessage([]byte("hello")),
+	}, noopAckFn))
+
+	// Simulates the buffer closing whilst a read is decoding the batch.
+	d.codecMut.Lock()
+	d.codecsClosed = true
+	d.codecMut.Unlock()
+
+	_, _, err := d.ReadBatch(tCtx)
+	require.ErrorIs(t, err, service.ErrEndOfBuffer)
+
+	// The batch must not be treated as corrupt and removed.
+	d = newTestDiskBuffer(t, `
+path: %v
+compression: zstd
+`, dir)
+
+	batch, ackFn, err := d.ReadBatch(tCtx)
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	b, err := batch[0].AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+	require.NoError(t, ackFn(tCtx, nil))
+}
+
+func TestDiskBufferCorruptLength(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	dir := t.TempDir()
+
+	// A raw batch of one message without metadata, where the length of the
+	// message claims to be far larger than the file.
+	corrupt := []byte{diskBatchRaw, 0, 0, 0, 1, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 'h', 'i'}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "00000000000000000001"+diskBufferFileExt), corrupt, 0o644))
+
+	d := newTestDiskBuffer(t, `path: %v`, dir)
+
+	_, _, err := d.ReadBatch(tCtx)
+	require.Error(t, err)
+
+	_, err = os.Stat(filepath.Join(dir, "00000000000000000001"+diskBufferFileExt))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
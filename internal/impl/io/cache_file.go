@@ -3,22 +3,68 @@ package io
 import (
 	"context"
 	"errors"
+	"fmt"
+	"hash/fnv"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/redpanda-data/benthos/v4/internal/filepath/ifs"
 	"github.com/redpanda-data/benthos/v4/public/service"
 )
 
+const (
+	fcFieldDirectory          = "directory"
+	fcFieldDefaultTTL         = "default_ttl"
+	fcFieldCompactionInterval = "compaction_interval"
+	fcFieldShards             = "shards"
+
+	// The directory within the cache directory that holds item expiry
+	// timestamps, mirroring the layout of the items themselves.
+	fileCacheExpiryDir = ".expiry"
+
+	// The number of locks that items are spread across in order to serialise
+	// changes to an item and its expiry timestamp.
+	fileCacheLockStripes = 64
+)
+
 func fileCacheConfig() *service.ConfigSpec {
 	spec := service.NewConfigSpec().
 		Stable().
 		Summary(`Stores each item in a directory as a file, where an item ID is the path relative to the configured directory.`).
-		Description(`This type currently offers no form of item expiry or garbage collection, and is intended to be used for development and debugging purposes only.`).
-		Field(service.NewStringField("directory").
-			Description("The directory within which to store items."))
+		Description(`
+== Item expiry
+
+Item IDs must not resolve to the cache directory itself, a path outside of it, or a path within the ` + "`" + fileCacheExpiryDir + "`" + ` directory.
+
+Items written with a TTL, either explicitly or via the field ` + "`default_ttl`" + `, are stored alongside an expiry timestamp kept within a hidden ` + "`" + fileCacheExpiryDir + "`" + ` directory. Expired items are treated as missing when read, and are removed from disk the next time they're accessed or during the next compaction. Items written without a TTL never expire.
+
+Compactions are performed in the background at the period specified by ` + "`compaction_interval`" + `, and walk all items that have an expiry in order to remove those that have expired. Without compactions expired items that are never accessed again remain on disk.
+
+== Sharding
+
+When ` + "`shards`" + ` is set to a value greater than zero items are spread across that number of sub directories according to a hash of their key, which avoids very large flat directories when storing many items. Changing the number of shards of an existing cache directory results in previously stored items no longer being found.`).
+		Field(service.NewStringField(fcFieldDirectory).
+			Description("The directory within which to store items.")).
+		Field(service.NewDurationField(fcFieldDefaultTTL).
+			Description("An optional default TTL to apply to items written without one. After this period an item is treated as missing and will be removed during the next compaction.").
+			Optional().
+			Example("1h").
+			Version("4.39.0")).
+		Field(service.NewDurationField(fcFieldCompactionInterval).
+			Description("The period of time to wait between each compaction, at which point expired items are removed from disk. This field can be set to an empty string in order to disable background compactions.").
+			Default("").
+			Example("5m").
+			Version("4.39.0")).
+		Field(service.NewIntField(fcFieldShards).
+			Description("A number of sub directories to spread items across. Set to zero in order to store all items directly within the configured directory.").
+			Default(0).
+			Advanced().
+			Version("4.39.0"))
 
 	return spec
 }
@@ -39,38 +85,215 @@ func init() {
 }
 
 func newFileCacheFromConfig(conf *service.ParsedConfig, mgr *service.Resources) (*fileCache, error) {
-	directory, err := conf.FieldString("directory")
+	directory, err := conf.FieldString(fcFieldDirectory)
 	if err != nil {
 		return nil, err
 	}
-	return newFileCache(directory, mgr), nil
+
+	f := newFileCache(directory, mgr)
+	if conf.Contains(fcFieldDefaultTTL) {
+		if f.defaultTTL, err = conf.FieldDuration(fcFieldDefaultTTL); err != nil {
+			return nil, err
+		}
+	}
+	if f.shards, err = conf.FieldInt(fcFieldShards); err != nil {
+		return nil, err
+	}
+	if f.shards < 0 {
+		return nil, errors.New("number of shards must not be negative")
+	}
+
+	var compInterval time.Duration
+	if test, _ := conf.FieldString(fcFieldCompactionInterval); test != "" {
+		if compInterval, err = conf.FieldDuration(fcFieldCompactionInterval); err != nil {
+			return nil, err
+		}
+	}
+	if compInterval > 0 {
+		f.startCompactions(compInterval)
+	}
+	return f, nil
 }
 
 //------------------------------------------------------------------------------
 
 func newFileCache(dir string, mgr *service.Resources) *fileCache {
-	return &fileCache{mgr: mgr, dir: dir}
+	return &fileCache{mgr: mgr, fs: mgr.FS(), dir: dir}
 }
 
 type fileCache struct {
 	mgr *service.Resources
+	fs  *service.FS
 	dir string
+
+	defaultTTL time.Duration
+	shards     int
+
+	locks [fileCacheLockStripes]sync.Mutex
+
+	closeOnce  sync.Once
+	closeChan  chan struct{}
+	closedChan chan struct{}
+}
+
+// relPath returns the path of an item relative to the cache directory.
+func (f *fileCache) relPath(key string) string {
+	if f.shards <= 0 {
+		return key
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return filepath.Join(strconv.Itoa(int(h.Sum32()%uint32(f.shards))), key)
+}
+
+// lockFor returns the lock that guards changes to the item at a path relative
+// to the cache directory, along with its expiry timestamp.
+func (f *fileCache) lockFor(relPath string) *sync.Mutex {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(relPath))
+	return &f.locks[h.Sum32()%fileCacheLockStripes]
+}
+
+// checkKey returns an error if a key would resolve to a path that collides
+// with the cache directory, a shard directory or the expiry directory.
+func checkKey(key string) error {
+	clean := filepath.Clean(key)
+	if clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("key '%v' must resolve to a path within the cache directory", key)
+	}
+	if clean == fileCacheExpiryDir || strings.HasPrefix(clean, fileCacheExpiryDir+string(filepath.Separator)) {
+		return fmt.Errorf("key '%v' must not be within the reserved directory %v", key, fileCacheExpiryDir)
+	}
+	return nil
+}
+
+func (f *fileCache) itemPath(key string) string {
+	return filepath.Join(f.dir, f.relPath(key))
+}
+
+func (f *fileCache) expiryPath(key string) string {
+	return filepath.Join(f.dir, fileCacheExpiryDir, f.relPath(key))
+}
+
+func (f *fileCache) ttlFor(ttl *time.Duration) time.Duration {
+	if ttl != nil {
+		return *ttl
+	}
+	return f.defaultTTL
+}
+
+func (f *fileCache) mkdirFor(path string) error {
+	if f.shards <= 0 && filepath.Dir(path) == filepath.Clean(f.dir) {
+		return nil
+	}
+	return f.fs.MkdirAll(filepath.Dir(path), 0o755)
+}
+
+// readExpiry returns the expiry time of an item, which is zero if the item
+// does not expire.
+func (f *fileCache) readExpiry(path string) (time.Time, error) {
+	b, err := ifs.ReadFile(f.fs, path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	nanos, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, nanos), nil
+}
+
+func (f *fileCache) writeExpiry(key string, ttl time.Duration) error {
+	path := f.expiryPath(key)
+	if ttl <= 0 {
+		if err := f.fs.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if err := f.fs.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	expires := time.Now().Add(ttl).UnixNano()
+	return ifs.WriteFile(f.fs, path, []byte(strconv.FormatInt(expires, 10)), 0o644)
+}
+
+// removeIfExpired removes an item and its expiry when the item has expired,
+// and returns true if the item was removed.
+func (f *fileCache) removeIfExpired(itemPath, expiryPath string) (bool, error) {
+	expires, err := f.readExpiry(expiryPath)
+	if err != nil {
+		return false, err
+	}
+	if expires.IsZero() || expires.After(time.Now()) {
+		return false, nil
+	}
+	if err := f.fs.Remove(itemPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	if err := f.fs.Remove(expiryPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	return true, nil
 }
 
 func (f *fileCache) Get(_ context.Context, key string) ([]byte, error) {
-	b, err := ifs.ReadFile(f.mgr.FS(), filepath.Join(f.dir, key))
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	l := f.lockFor(f.relPath(key))
+	l.Lock()
+	defer l.Unlock()
+
+	if expired, err := f.removeIfExpired(f.itemPath(key), f.expiryPath(key)); err != nil {
+		return nil, err
+	} else if expired {
+		return nil, service.ErrKeyNotFound
+	}
+	b, err := ifs.ReadFile(f.fs, f.itemPath(key))
 	if errors.Is(err, fs.ErrNotExist) {
 		return nil, service.ErrKeyNotFound
 	}
 	return b, err
 }
 
-func (f *fileCache) Set(_ context.Context, key string, value []byte, _ *time.Duration) error {
-	return ifs.WriteFile(f.mgr.FS(), filepath.Join(f.dir, key), value, 0o644)
+func (f *fileCache) Set(_ context.Context, key string, value []byte, ttl *time.Duration) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	l := f.lockFor(f.relPath(key))
+	l.Lock()
+	defer l.Unlock()
+
+	path := f.itemPath(key)
+	if err := f.mkdirFor(path); err != nil {
+		return err
+	}
+	if err := ifs.WriteFile(f.fs, path, value, 0o644); err != nil {
+		return err
+	}
+	return f.writeExpiry(key, f.ttlFor(ttl))
 }
 
-func (f *fileCache) Add(_ context.Context, key string, value []byte, _ *time.Duration) error {
-	file, err := f.mgr.FS().OpenFile(filepath.Join(f.dir, key), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
+func (f *fileCache) Add(_ context.Context, key string, value []byte, ttl *time.Duration) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	l := f.lockFor(f.relPath(key))
+	l.Lock()
+	defer l.Unlock()
+
+	path := f.itemPath(key)
+	if _, err := f.removeIfExpired(path, f.expiryPath(key)); err != nil {
+		return err
+	}
+	if err := f.mkdirFor(path); err != nil {
+		return err
+	}
+	file, err := f.fs.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
 	if err != nil {
 		if errors.Is(err, fs.ErrExist) {
 			return service.ErrKeyAlreadyExists
@@ -81,13 +304,92 @@ func (f *fileCache) Add(_ context.Context, key string, value []byte, _ *time.Dur
 		file.Close()
 		return err
 	}
-	return file.Close()
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return f.writeExpiry(key, f.ttlFor(ttl))
 }
 
 func (f *fileCache) Delete(_ context.Context, key string) error {
-	return f.mgr.FS().Remove(filepath.Join(f.dir, key))
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	l := f.lockFor(f.relPath(key))
+	l.Lock()
+	defer l.Unlock()
+
+	if err := f.fs.Remove(f.itemPath(key)); err != nil {
+		return err
+	}
+	return f.writeExpiry(key, 0)
 }
 
-func (f *fileCache) Close(context.Context) error {
+//------------------------------------------------------------------------------
+
+func (f *fileCache) startCompactions(interval time.Duration) {
+	f.closeChan = make(chan struct{})
+	f.closedChan = make(chan struct{})
+
+	go func() {
+		defer close(f.closedChan)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := f.compact(); err != nil {
+					f.mgr.Logger().Errorf("Failed to compact file cache: %v", err)
+				}
+			case <-f.closeChan:
+				return
+			}
+		}
+	}()
+}
+
+// compact walks all item expiry timestamps and removes any items that have
+// expired.
+func (f *fileCache) compact() error {
+	expiryRoot := filepath.Join(f.dir, fileCacheExpiryDir)
+	err := fs.WalkDir(f.fs, expiryRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(expiryRoot, path)
+		if err != nil {
+			return err
+		}
+		l := f.lockFor(rel)
+		l.Lock()
+		_, err = f.removeIfExpired(filepath.Join(f.dir, rel), path)
+		l.Unlock()
+		return err
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (f *fileCache) Close(ctx context.Context) error {
+	if f.closeChan == nil {
+		return nil
+	}
+	f.closeOnce.Do(func() {
+		close(f.closeChan)
+	})
+	select {
+	case <-f.closedChan:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 	return nil
 }
@@ -2,12 +2,19 @@ package io
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/redpanda-data/benthos/v4/internal/filepath/ifs"
 	"github.com/redpanda-data/benthos/v4/public/service"
 )
 
@@ -48,3 +55,145 @@ func TestFileCache(t *testing.T) {
 	_, err = c.Get(tCtx, "foo")
 	assert.Equal(t, service.ErrKeyNotFound, err)
 }
+
+func TestFileCacheTTL(t *testing.T) {
+	dir := t.TempDir()
+
+	tCtx := context.Background()
+	c := newFileCache(dir, service.MockResources())
+
+	ttl := time.Millisecond * 50
+	require.NoError(t, c.Set(tCtx, "foo", []byte("1"), &ttl))
+	require.NoError(t, c.Add(tCtx, "bar", []byte("2"), &ttl))
+	require.NoError(t, c.Set(tCtx, "baz", []byte("3"), nil))
+
+	act, err := c.Get(tCtx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(act))
+
+	assert.Equal(t, service.ErrKeyAlreadyExists, c.Add(tCtx, "bar", []byte("4"), nil))
+
+	time.Sleep(ttl * 2)
+
+	_, err = c.Get(tCtx, "foo")
+	assert.Equal(t, service.ErrKeyNotFound, err)
+	assert.NoFileExists(t, filepath.Join(dir, "foo"))
+
+	require.NoError(t, c.Add(tCtx, "bar", []byte("4"), nil))
+
+	act, err = c.Get(tCtx, "bar")
+	require.NoError(t, err)
+	assert.Equal(t, "4", string(act))
+
+	time.Sleep(ttl * 2)
+
+	act, err = c.Get(tCtx, "bar")
+	require.NoError(t, err)
+	assert.Equal(t, "4", string(act))
+
+	act, err = c.Get(tCtx, "baz")
+	require.NoError(t, err)
+	assert.Equal(t, "3", string(act))
+}
+
+func TestFileCacheCompactionAndShards(t *testing.T) {
+	dir := t.TempDir()
+
+	pConf, err := fileCacheConfig().ParseYAML(fmt.Sprintf(`
+directory: %v
+default_ttl: 50ms
+compaction_interval: 10ms
+shards: 4
+`, dir), nil)
+	require.NoError(t, err)
+
+	tCtx := context.Background()
+	c, err := newFileCacheFromConfig(pConf, service.MockResources())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, c.Close(tCtx))
+	})
+
+	noTTL := time.Duration(0)
+	require.NoError(t, c.Set(tCtx, "foo", []byte("1"), nil))
+	require.NoError(t, c.Set(tCtx, "bar", []byte("2"), &noTTL))
+
+	fooPath := c.itemPath("foo")
+	assert.NotEqual(t, filepath.Join(dir, "foo"), fooPath)
+	assert.FileExists(t, fooPath)
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(fooPath)
+		return errors.Is(err, os.ErrNotExist)
+	}, time.Second, time.Millisecond*10)
+
+	act, err := c.Get(tCtx, "bar")
+	require.NoError(t, err)
+	assert.Equal(t, "2", string(act))
+}
+
+func TestFileCacheReservedKeys(t *testing.T) {
+	tCtx := context.Background()
+	for _, shards := range []int{0, 4} {
+		c := newFileCache(t.TempDir(), service.MockResources())
+		c.shards = shards
+
+		for _, key := range []string{"", ".", "..", "../foo", "foo/../..", ".expiry", ".expiry/foo"} {
+			assert.Error(t, c.Set(tCtx, key, []byte("1"), nil), key)
+			assert.Error(t, c.Add(tCtx, key, []byte("1"), nil), key)
+			_, err := c.Get(tCtx, key)
+			assert.Error(t, err, key)
+			assert.NotErrorIs(t, err, service.ErrKeyNotFound, key)
+		}
+
+		require.NoError(t, c.Set(tCtx, "foo/.expiry", []byte("1"), nil))
+		act, err := c.Get(tCtx, "foo/.expiry")
+		require.NoError(t, err)
+		assert.Equal(t, "1", string(act))
+	}
+}
+
+// hookFS calls a function before creating a directory.
+type hookFS struct {
+	ifs.FS
+	beforeMkdir func(path string)
+}
+
+func (h hookFS) MkdirAll(path string, perm fs.FileMode) error {
+	h.beforeMkdir(path)
+	return h.FS.MkdirAll(path, perm)
+}
+
+func TestFileCacheCompactionDuringSet(t *testing.T) {
+	dir := t.TempDir()
+	tCtx := context.Background()
+	c := newFileCache(dir, service.MockResources())
+
+	shortTTL, longTTL := time.Nanosecond, time.Hour
+	require.NoError(t, c.Set(tCtx, "foo", []byte("old"), &shortTTL))
+
+	// Attempt a compaction after the new item has been written but before its
+	// expiry has been updated, which must not remove the new item.
+	var once sync.Once
+	compacted := make(chan error, 1)
+	c.fs = service.NewFS(hookFS{
+		FS: ifs.OS(),
+		beforeMkdir: func(path string) {
+			once.Do(func() {
+				go func() {
+					compacted <- c.compact()
+				}()
+				// Give the compaction a chance to complete, which it should
+				// not be able to do until the item has been fully written.
+				time.Sleep(time.Millisecond * 100)
+			})
+		},
+	})
+
+	require.NoError(t, c.Set(tCtx, "foo", []byte("new"), &longTTL))
+	require.NoError(t, <-compacted)
+
+	act, err := c.Get(tCtx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(act))
+}
@@ -44,6 +44,8 @@ Creates or updates an input with a configuration provided in the request body (i
 
 Stops and removes an input.
 
+While an input is being updated or removed any further requests to update or remove it are rejected with a 409 Conflict status code.
+
 === GET `+"`/inputs/\\{id}/uptime`"+`
 
 Returns the uptime of an input as a duration string (of the form "72h3m0.5s"), or "stopped" in the case where the input has gracefully terminated.`).
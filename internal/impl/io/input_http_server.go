@@ -53,6 +53,16 @@ const (
 	hsiFieldCORS                    = "cors"
 	hsiFieldCORSEnabled             = "enabled"
 	hsiFieldCORSAllowedOrigins      = "allowed_origins"
+	hsiFieldCORSAllowedMethods      = "allowed_methods"
+	hsiFieldCORSAllowedHeaders      = "allowed_headers"
+	hsiFieldCORSAllowCredentials    = "allow_credentials"
+	hsiFieldBasicAuth               = "basic_auth"
+	hsiFieldBasicAuthEnabled        = "enabled"
+	hsiFieldBasicAuthRealm          = "realm"
+	hsiFieldBasicAuthUsername       = "username"
+	hsiFieldBasicAuthPasswordHash   = "password_hash"
+	hsiFieldBasicAuthAlgorithm      = "algorithm"
+	hsiFieldBasicAuthSalt           = "salt"
 	hsiFieldResponse                = "sync_response"
 	hsiFieldResponseStatus          = "status"
 	hsiFieldResponseHeaders         = "headers"
@@ -71,6 +81,7 @@ type hsiConfig struct {
 	CertFile           string
 	KeyFile            string
 	CORS               httpserver.CORSConfig
+	BasicAuth          httpserver.BasicAuthConfig
 	Response           hsiResponseConfig
 }
 
@@ -125,6 +136,9 @@ func hsiConfigFromParsed(pConf *service.ParsedConfig) (conf hsiConfig, err error
 	if conf.CORS, err = corsConfigFromParsed(pConf.Namespace(hsiFieldCORS)); err != nil {
 		return
 	}
+	if conf.BasicAuth, err = basicAuthConfigFromParsed(pConf.Namespace(hsiFieldBasicAuth)); err != nil {
+		return
+	}
 	if conf.Response, err = hsiResponseConfigFromParsed(pConf.Namespace(hsiFieldResponse)); err != nil {
 		return
 	}
@@ -138,9 +152,62 @@ func corsConfigFromParsed(pConf *service.ParsedConfig) (conf httpserver.CORSConf
 	if conf.AllowedOrigins, err = pConf.FieldStringList(hsiFieldCORSAllowedOrigins); err != nil {
 		return
 	}
+	if conf.AllowedMethods, err = pConf.FieldStringList(hsiFieldCORSAllowedMethods); err != nil {
+		return
+	}
+	if conf.AllowedHeaders, err = pConf.FieldStringList(hsiFieldCORSAllowedHeaders); err != nil {
+		return
+	}
+	if conf.AllowCredentials, err = pConf.FieldBool(hsiFieldCORSAllowCredentials); err != nil {
+		return
+	}
 	return
 }
 
+func basicAuthConfigFromParsed(pConf *service.ParsedConfig) (conf httpserver.BasicAuthConfig, err error) {
+	if conf.Enabled, err = pConf.FieldBool(hsiFieldBasicAuthEnabled); err != nil {
+		return
+	}
+	if conf.Realm, err = pConf.FieldString(hsiFieldBasicAuthRealm); err != nil {
+		return
+	}
+	if conf.Username, err = pConf.FieldString(hsiFieldBasicAuthUsername); err != nil {
+		return
+	}
+	if conf.PasswordHash, err = pConf.FieldString(hsiFieldBasicAuthPasswordHash); err != nil {
+		return
+	}
+	if conf.Algorithm, err = pConf.FieldString(hsiFieldBasicAuthAlgorithm); err != nil {
+		return
+	}
+	if conf.Salt, err = pConf.FieldString(hsiFieldBasicAuthSalt); err != nil {
+		return
+	}
+	err = conf.Validate()
+	return
+}
+
+// serverHandler wraps the router of an http_server component hosted on a
+// custom address with the configured CORS and basic authentication
+// middleware.
+func serverHandler(gMux *mux.Router, cors httpserver.CORSConfig, basicAuth httpserver.BasicAuthConfig) (http.Handler, error) {
+	handler, err := cors.WrapHandler(basicAuth.WrapHandler(gMux.ServeHTTP))
+	if err != nil {
+		return nil, fmt.Errorf("bad CORS configuration: %w", err)
+	}
+	return handler, nil
+}
+
+// basicAuthLintRule flags basic authentication enabled without a custom
+// address, as it would otherwise be silently ignored.
+const basicAuthLintRule = `root = if this.basic_auth.enabled.or(false) && this.address.or("") == "" { "basic_auth can only be enabled with a custom address" }`
+
+func basicAuthFieldSpec() *service.ConfigField {
+	basicAuthSpec := httpserver.BasicAuthFieldSpec().AtVersion("4.39.0")
+	basicAuthSpec.Description += " Only valid with a custom `address`, otherwise the basic authentication of the service-wide HTTP server applies."
+	return service.NewInternalField(basicAuthSpec)
+}
+
 func hsiResponseConfigFromParsed(pConf *service.ParsedConfig) (conf hsiResponseConfig, err error) {
 	if conf.Status, err = pConf.FieldInterpolatedString(hsiFieldResponseStatus); err != nil {
 		return
@@ -253,6 +320,7 @@ You can access these metadata fields using xref:configuration:interpolation.adoc
 				Advanced().
 				Default(""),
 			service.NewInternalField(corsSpec),
+			basicAuthFieldSpec(),
 			service.NewObjectField(hsiFieldResponse,
 				service.NewInterpolatedStringField(hsiFieldResponseStatus).
 					Description("Specify the status code to return with synchronous responses. This is a string value, which allows you to customize it based on resulting payloads and their metadata.").
@@ -269,6 +337,7 @@ You can access these metadata fields using xref:configuration:interpolation.adoc
 				Description("Customize messages returned via xref:guides:sync_responses.adoc[synchronous responses].").
 				Advanced(),
 		).
+		LintRule(basicAuthLintRule).
 		Example(
 			"Path Switching",
 			"This example shows an `http_server` input that captures all requests and processes them by switching on that path:", `
@@ -376,8 +445,8 @@ func newHTTPServerInput(conf hsiConfig, mgr bundle.NewManagement) (input.Streame
 	if conf.Address != "" {
 		gMux = mux.NewRouter()
 		server = &http.Server{Addr: conf.Address}
-		if server.Handler, err = conf.CORS.WrapHandler(gMux); err != nil {
-			return nil, fmt.Errorf("bad CORS configuration: %w", err)
+		if server.Handler, err = serverHandler(gMux, conf.CORS, conf.BasicAuth); err != nil {
+			return nil, err
 		}
 	}
 
@@ -31,6 +31,7 @@ import (
 	"github.com/redpanda-data/benthos/v4/internal/manager/mock"
 	"github.com/redpanda-data/benthos/v4/internal/message"
 	"github.com/redpanda-data/benthos/v4/internal/transaction"
+	"github.com/redpanda-data/benthos/v4/public/service"
 
 	_ "github.com/redpanda-data/benthos/v4/public/components/pure"
 )
@@ -1336,3 +1337,102 @@ http_server:
 	assert.Equal(t, "200 OK", resp.Status)
 	assert.Equal(t, "foo", resp.Header.Get("Access-Control-Allow-Origin"))
 }
+
+func TestHTTPServerInputBasicAuth(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Minute)
+	defer done()
+
+	freePort := getFreePort(t)
+
+	conf := parseYAMLInputConf(t, `
+http_server:
+  address: 0.0.0.0:%v
+  path: /test
+  basic_auth:
+    enabled: true
+    username: myuser
+    password_hash: K7gNU3sdo+OL0wNhqoVWhr3g6s1xYv72ol/pe/Unols=
+`, freePort)
+
+	server, err := mock.NewManager().NewInput(conf)
+	require.NoError(t, err)
+
+	defer func() {
+		server.TriggerStopConsuming()
+		assert.NoError(t, server.WaitForClose(tCtx))
+	}()
+
+	go func() {
+		select {
+		case tran, open := <-server.TransactionChan():
+			if !open {
+				return
+			}
+			_ = tran.Ack(tCtx, nil)
+		case <-tCtx.Done():
+		}
+	}()
+
+	postWithAuth := func(user, pass string) (status int, err error) {
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://localhost:%v/test", freePort), bytes.NewBufferString("hello"))
+		if err != nil {
+			return 0, err
+		}
+		if user != "" {
+			req.SetBasicAuth(user, pass)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	var status int
+	require.Eventually(t, func() bool {
+		status, err = postWithAuth("myuser", "wrong")
+		return err == nil
+	}, time.Second, 50*time.Millisecond)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, err = postWithAuth("", "")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, err = postWithAuth("myuser", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestHTTPServerBasicAuthLint(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		addFn   func(b *service.StreamBuilder, conf string) error
+		address string
+		lintErr bool
+	}{
+		{name: "input without address", addFn: (*service.StreamBuilder).AddInputYAML, lintErr: true},
+		{name: "input with address", addFn: (*service.StreamBuilder).AddInputYAML, address: "0.0.0.0:4196"},
+		{name: "output without address", addFn: (*service.StreamBuilder).AddOutputYAML, lintErr: true},
+		{name: "output with address", addFn: (*service.StreamBuilder).AddOutputYAML, address: "0.0.0.0:4196"},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := test.addFn(service.NewStreamBuilder(), fmt.Sprintf(`
+http_server:
+  address: "%v"
+  basic_auth:
+    enabled: true
+    username: myuser
+    password_hash: K7gNU3sdo+OL0wNhqoVWhr3g6s1xYv72ol/pe/Unols=
+`, test.address))
+			if test.lintErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "basic_auth can only be enabled with a custom address")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
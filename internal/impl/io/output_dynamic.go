@@ -42,9 +42,13 @@ Returns the configuration of an output.
 
 Creates or updates an output with a configuration provided in the request body (in YAML or JSON format).
 
+When updating an existing output the swap is atomic: the new output is started and begins receiving messages before the old output is drained of any messages that are in flight and then closed. The request does not complete until the old output has been drained.
+
 === DELETE `+"`/outputs/\\{id}`"+`
 
-Stops and removes an output.
+Stops and removes an output. The output stops receiving new messages immediately, and the request does not complete until any messages in flight have been delivered and acknowledged.
+
+While an output is being updated or removed any further requests to update or remove it are rejected with a 409 Conflict status code.
 
 === GET `+"`/outputs/\\{id}/uptime`"+`
 
@@ -80,8 +80,9 @@ func newDynamicFanOutOutputBroker(
 }
 
 // SetOutput attempts to add a new output to the dynamic output broker. If an
-// output already exists with the same identifier it will be closed and removed.
-// If either action takes longer than the timeout period an error will be
+// output already exists with the same identifier it is swapped out for the new
+// output and is then drained of any in flight messages before being closed. If
+// either action takes longer than the timeout period an error will be
 // returned.
 //
 // A nil output argument is safe and will simply remove the previous output
@@ -136,19 +137,17 @@ func (d *dynamicFanOutOutputBroker) addOutput(ident string, output output.Stream
 	return nil
 }
 
-func (d *dynamicFanOutOutputBroker) removeOutput(ctx context.Context, ident string) error {
-	ow, exists := d.outputs[ident]
-	if !exists {
-		return nil
-	}
-
-	ow.output.TriggerCloseNow()
+// drainOutput closes the transaction channel of a detached output, allowing it
+// to finish sending (and acknowledging) any messages that are in flight before
+// it shuts down. If the context is cancelled before the output has finished
+// draining then it is forcefully closed.
+func (d *dynamicFanOutOutputBroker) drainOutput(ctx context.Context, ow outputWithTSChan) error {
+	close(ow.tsChan)
 	err := ow.output.WaitForClose(ctx)
-
+	if err != nil {
+		ow.output.TriggerCloseNow()
+	}
 	ow.done()
-	close(ow.tsChan)
-	delete(d.outputs, ident)
-
 	return err
 }
 
@@ -200,28 +199,35 @@ func (d *dynamicFanOutOutputBroker) loop() {
 				}
 				func() {
 					d.outputsMut.Lock()
-					defer d.outputsMut.Unlock()
 
-					// First, always remove the previous output if it exists.
-					if _, exists := d.outputs[wrappedOutput.Name]; exists {
-						if err := d.removeOutput(wrappedOutput.Ctx, wrappedOutput.Name); err != nil {
-							d.log.Error("Failed to stop old copy of dynamic output '%v' in time: %v, the output will continue to shut down in the background.\n", wrappedOutput.Name, err)
-						}
+					// First, detach the previous output if it exists so that
+					// it no longer receives new messages.
+					oldOutput, hadOld := d.outputs[wrappedOutput.Name]
+					if hadOld {
+						delete(d.outputs, wrappedOutput.Name)
 						d.onRemove(wrappedOutput.Name)
 					}
 
-					// Next, attempt to create a new output (if specified).
-					if wrappedOutput.Output == nil {
-						wrappedOutput.ResChan <- nil
-					} else {
-						err := d.addOutput(wrappedOutput.Name, wrappedOutput.Output)
-						if err != nil {
+					// Next, attempt to create a new output (if specified),
+					// which takes the place of the old output atomically.
+					var err error
+					if wrappedOutput.Output != nil {
+						if err = d.addOutput(wrappedOutput.Name, wrappedOutput.Output); err != nil {
 							d.log.Error("Failed to start new dynamic output '%v': %v\n", wrappedOutput.Name, err)
 						} else {
 							d.onAdd(wrappedOutput.Name)
 						}
-						wrappedOutput.ResChan <- err
 					}
+					d.outputsMut.Unlock()
+
+					// Finally, drain the old output outside of the lock so
+					// that messages continue to flow whilst it shuts down.
+					if hadOld {
+						if dErr := d.drainOutput(wrappedOutput.Ctx, oldOutput); dErr != nil {
+							d.log.Error("Failed to drain old copy of dynamic output '%v' in time: %v, the output will continue to shut down in the background.\n", wrappedOutput.Name, dErr)
+						}
+					}
+					wrappedOutput.ResChan <- err
 				}()
 			case <-d.shutSig.SoftStopChan():
 				return
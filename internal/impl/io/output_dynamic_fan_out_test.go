@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/redpanda-data/benthos/v4/internal/component/output"
+	"github.com/redpanda-data/benthos/v4/internal/impl/pure"
 	"github.com/redpanda-data/benthos/v4/internal/log"
 	"github.com/redpanda-data/benthos/v4/internal/manager/mock"
 	"github.com/redpanda-data/benthos/v4/internal/message"
@@ -247,6 +248,88 @@ func TestDynamicFanOutAtLeastOnce(t *testing.T) {
 	require.NoError(t, oTM.WaitForClose(tCtx))
 }
 
+func TestDynamicFanOutSwapDrains(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	mockOld := &mock.OutputChanneled{}
+	mockNew := &mock.OutputChanneled{}
+
+	oldOutput, err := pure.RetryOutputIndefinitely(mock.NewManager(), mockOld)
+	require.NoError(t, err)
+
+	added := make(chan string, 2)
+	readChan := make(chan message.Transaction)
+	oTM, err := newDynamicFanOutOutputBroker(map[string]output.Streamed{
+		"foo": oldOutput,
+	}, log.Noop(), func(label string) {
+		added <- label
+	}, nil)
+	require.NoError(t, err)
+	require.NoError(t, oTM.Consume(readChan))
+	require.Equal(t, "foo", <-added)
+
+	sendMsg := func(content string) chan error {
+		resChan := make(chan error, 1)
+		select {
+		case readChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte(content)}), resChan):
+		case <-tCtx.Done():
+			t.Fatal(tCtx.Err())
+		}
+		return resChan
+	}
+
+	firstRes := sendMsg("first")
+
+	var oldTran message.Transaction
+	select {
+	case oldTran = <-mockOld.TChan:
+	case <-tCtx.Done():
+		t.Fatal(tCtx.Err())
+	}
+
+	setErrChan := make(chan error, 1)
+	go func() {
+		setErrChan <- oTM.SetOutput(tCtx, "foo", mockNew)
+	}()
+
+	select {
+	case label := <-added:
+		require.Equal(t, "foo", label)
+	case <-tCtx.Done():
+		t.Fatal(tCtx.Err())
+	}
+
+	// Messages should flow to the new output whilst the old one drains.
+	secondRes := sendMsg("second")
+	select {
+	case ts := <-mockNew.TChan:
+		assert.Equal(t, "second", string(ts.Payload.Get(0).AsBytes()))
+		require.NoError(t, ts.Ack(tCtx, nil))
+	case <-tCtx.Done():
+		t.Fatal(tCtx.Err())
+	}
+	require.NoError(t, <-secondRes)
+
+	select {
+	case <-setErrChan:
+		t.Fatal("Expected swap to block until the old output is drained")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	require.NoError(t, oldTran.Ack(tCtx, nil))
+	select {
+	case err := <-setErrChan:
+		require.NoError(t, err)
+	case <-tCtx.Done():
+		t.Fatal(tCtx.Err())
+	}
+	require.NoError(t, <-firstRes)
+
+	oTM.TriggerCloseNow()
+	require.NoError(t, oTM.WaitForClose(tCtx))
+}
+
 func TestDynamicFanOutStartEmpty(t *testing.T) {
 	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
 	defer done()
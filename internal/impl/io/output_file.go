@@ -1,6 +1,7 @@
 package io
 
 import (
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -9,22 +10,41 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/redpanda-data/benthos/v4/internal/codec"
 	"github.com/redpanda-data/benthos/v4/public/service"
 )
 
 const (
-	fileOutputFieldPath  = "path"
-	fileOutputFieldCodec = "codec"
+	fileOutputFieldPath             = "path"
+	fileOutputFieldCodec            = "codec"
+	fileOutputFieldRotation         = "rotation"
+	fileOutputFieldRotationMaxSize  = "max_size"
+	fileOutputFieldRotationMaxAge   = "max_age"
+	fileOutputFieldRotationCompress = "compress"
+	fileOutputFieldSyncPolicy       = "sync_policy"
 )
 
+const (
+	fileSyncPolicyNone  = "none"
+	fileSyncPolicyWrite = "write"
+	fileSyncPolicyClose = "close"
+)
+
+// The timestamp format appended to the path of rotated files.
+const fileRotationTimeFormat = "20060102T150405.000000000"
+
 func fileOutputSpec() *service.ConfigSpec {
 	return service.NewConfigSpec().
 		Stable().
 		Categories("Local").
 		Summary(`Writes messages to files on disk based on a chosen codec.`).
-		Description(`Messages can be written to different files by using xref:configuration:interpolation.adoc#bloblang-queries[interpolation functions] in the path field. However, only one file is ever open at a given time, and therefore when the path changes the previously open file is closed.`).
+		Description(`Messages can be written to different files by using xref:configuration:interpolation.adoc#bloblang-queries[interpolation functions] in the path field. However, only one file is ever open at a given time, and therefore when the path changes the previously open file is closed.
+
+== Rotation
+
+When using a codec that appends messages to a file (such as `+"`lines`"+`) the open file can be rotated once it reaches a maximum size or age. Rotating a file renames it to the same path suffixed with the time of rotation (e.g. `+"`/tmp/data.txt.20240917T120000.000000000`"+`), optionally compressing it with gzip afterwards, and writing then resumes to a fresh file at the original path.`).
 		Fields(
 			service.NewInterpolatedStringField(fileOutputFieldPath).
 				Description("The file to write to, if the file does not yet exist it will be created.").
@@ -35,12 +55,40 @@ func fileOutputSpec() *service.ConfigSpec {
 				).
 				Version("3.33.0"),
 			service.NewInternalField(codec.NewWriterDocs(fileOutputFieldCodec)).Version("3.33.0").Default("lines"),
+			service.NewObjectField(fileOutputFieldRotation,
+				service.NewIntField(fileOutputFieldRotationMaxSize).
+					Description("The size in bytes at which a file is rotated, which is checked after each write and therefore a file may exceed it by up to the size of one message. Set to zero to disable size based rotation.").
+					Default(0),
+				service.NewDurationField(fileOutputFieldRotationMaxAge).
+					Description("The maximum period a file can be written to since it was opened before it is rotated. Set to zero to disable age based rotation.").
+					Default("0s"),
+				service.NewBoolField(fileOutputFieldRotationCompress).
+					Description("Whether rotated files should be compressed with gzip, in which case a `.gz` suffix is added to their path.").
+					Default(false),
+			).
+				Description("Rotation settings for files that are appended to. Rotation is disabled by default.").
+				Advanced().
+				Version("4.39.0"),
+			service.NewStringAnnotatedEnumField(fileOutputFieldSyncPolicy, map[string]string{
+				fileSyncPolicyNone:  "Files are never explicitly synced, leaving it to the operating system to flush writes to storage.",
+				fileSyncPolicyWrite: "Files are synced after each message is written.",
+				fileSyncPolicyClose: "Files are synced before they are closed or rotated.",
+			}).
+				Description("Determines when written data is explicitly flushed (fsync) to storage.").
+				Advanced().
+				Default(fileSyncPolicyNone).
+				Version("4.39.0"),
 		)
 }
 
 type fileOutputConfig struct {
 	Path  *service.InterpolatedString
 	Codec string
+
+	RotateMaxSize  int64
+	RotateMaxAge   time.Duration
+	RotateCompress bool
+	SyncPolicy     string
 }
 
 func fileOutputConfigFromParsed(pConf *service.ParsedConfig) (conf fileOutputConfig, err error) {
@@ -50,6 +98,20 @@ func fileOutputConfigFromParsed(pConf *service.ParsedConfig) (conf fileOutputCon
 	if conf.Codec, err = pConf.FieldString(fileOutputFieldCodec); err != nil {
 		return
 	}
+	var maxSize int
+	if maxSize, err = pConf.FieldInt(fileOutputFieldRotation, fileOutputFieldRotationMaxSize); err != nil {
+		return
+	}
+	conf.RotateMaxSize = int64(maxSize)
+	if conf.RotateMaxAge, err = pConf.FieldDuration(fileOutputFieldRotation, fileOutputFieldRotationMaxAge); err != nil {
+		return
+	}
+	if conf.RotateCompress, err = pConf.FieldBool(fileOutputFieldRotation, fileOutputFieldRotationCompress); err != nil {
+		return
+	}
+	if conf.SyncPolicy, err = pConf.FieldString(fileOutputFieldSyncPolicy); err != nil {
+		return
+	}
 	return
 }
 
@@ -62,7 +124,7 @@ func init() {
 			}
 
 			mif = 1
-			out, err = newFileWriter(conf.Path, conf.Codec, res)
+			out, err = newFileWriter(conf, res)
 			return
 		})
 	if err != nil {
@@ -80,22 +142,38 @@ type fileWriter struct {
 	suffixFn   codec.SuffixFn
 	appendMode bool
 
-	handleMut  sync.Mutex
-	handlePath string
-	handle     io.WriteCloser
+	rotateMaxSize  int64
+	rotateMaxAge   time.Duration
+	rotateCompress bool
+	syncPolicy     string
+
+	handleMut    sync.Mutex
+	handlePath   string
+	handle       io.WriteCloser
+	handleSize   int64
+	handleOpened time.Time
 }
 
-func newFileWriter(path *service.InterpolatedString, codecStr string, mgr *service.Resources) (*fileWriter, error) {
-	codec, appendMode, err := codec.GetWriter(codecStr)
+func newFileWriter(conf fileOutputConfig, mgr *service.Resources) (*fileWriter, error) {
+	codec, appendMode, err := codec.GetWriter(conf.Codec)
 	if err != nil {
 		return nil, err
 	}
+	switch conf.SyncPolicy {
+	case fileSyncPolicyNone, fileSyncPolicyWrite, fileSyncPolicyClose:
+	default:
+		return nil, fmt.Errorf("unrecognised sync_policy: %v", conf.SyncPolicy)
+	}
 	return &fileWriter{
-		suffixFn:   codec,
-		appendMode: appendMode,
-		path:       path,
-		log:        mgr.Logger(),
-		nm:         mgr,
+		suffixFn:       codec,
+		appendMode:     appendMode,
+		path:           conf.Path,
+		rotateMaxSize:  conf.RotateMaxSize,
+		rotateMaxAge:   conf.RotateMaxAge,
+		rotateCompress: conf.RotateCompress,
+		syncPolicy:     conf.SyncPolicy,
+		log:            mgr.Logger(),
+		nm:             mgr,
 	}, nil
 }
 
@@ -116,14 +194,122 @@ func (w *fileWriter) writeTo(wtr io.Writer, p *service.Message) error {
 	if _, err := wtr.Write(mBytes); err != nil {
 		return err
 	}
+	w.handleSize += int64(len(mBytes))
 	if addSuffix {
 		if _, err := wtr.Write(suffix); err != nil {
 			return err
 		}
+		w.handleSize += int64(len(suffix))
+	}
+	if w.syncPolicy == fileSyncPolicyWrite {
+		return syncFile(wtr)
 	}
 	return nil
 }
 
+func syncFile(f any) error {
+	if s, ok := f.(interface{ Sync() error }); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
+func (w *fileWriter) closeHandle() error {
+	if w.handle == nil {
+		return nil
+	}
+	handle := w.handle
+	w.handle = nil
+	if w.syncPolicy == fileSyncPolicyClose {
+		if err := syncFile(handle); err != nil {
+			_ = handle.Close()
+			return err
+		}
+	}
+	return handle.Close()
+}
+
+func (w *fileWriter) ageExceeded() bool {
+	return w.rotateMaxAge > 0 && time.Since(w.handleOpened) >= w.rotateMaxAge
+}
+
+// rotateIfFull rotates the currently open file once a write has caused it to
+// reach the maximum size. The message has already been written at this point
+// and so a failed rotation is logged rather than returned, as a returned error
+// would result in the message being written again.
+func (w *fileWriter) rotateIfFull() {
+	if w.rotateMaxSize <= 0 || w.handleSize < w.rotateMaxSize {
+		return
+	}
+	if err := w.rotate(); err != nil {
+		w.log.Errorf("Failed to rotate file '%v': %v", w.handlePath, err)
+	}
+}
+
+// rotate closes the currently open file and renames it to the same path
+// suffixed with the current time, optionally compressing it afterwards.
+func (w *fileWriter) rotate() error {
+	if err := w.closeHandle(); err != nil {
+		return err
+	}
+
+	rotatedPath := w.handlePath + "." + time.Now().UTC().Format(fileRotationTimeFormat)
+	if err := w.nm.FS().Rename(w.handlePath, rotatedPath); err != nil {
+		return err
+	}
+	w.log.Debugf("Rotated file '%v' to '%v'", w.handlePath, rotatedPath)
+
+	if w.rotateCompress {
+		// The data is safely rotated at this point, so a failure to compress
+		// leaves the uncompressed file in place.
+		if err := w.compress(rotatedPath); err != nil {
+			w.log.Errorf("Failed to compress rotated file '%v': %v", rotatedPath, err)
+		}
+	}
+	return nil
+}
+
+// compress writes a gzip compressed copy of a file to the same path with a
+// `.gz` suffix and then removes the original.
+func (w *fileWriter) compress(path string) (err error) {
+	gzPath := path + ".gz"
+
+	src, err := w.nm.FS().Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dstFile, err := w.nm.FS().OpenFile(gzPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fs.FileMode(0o666))
+	if err != nil {
+		return err
+	}
+	dst, ok := dstFile.(io.WriteCloser)
+	if !ok {
+		_ = dstFile.Close()
+		return errors.New("failed to open compressed file for writing")
+	}
+	defer func() {
+		if err == nil && w.syncPolicy != fileSyncPolicyNone {
+			err = syncFile(dst)
+		}
+		if cErr := dst.Close(); err == nil {
+			err = cErr
+		}
+		if err != nil {
+			_ = w.nm.FS().Remove(gzPath)
+			return
+		}
+		err = w.nm.FS().Remove(path)
+	}()
+
+	zw := gzip.NewWriter(dst)
+	if _, err = io.Copy(zw, src); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
 func (w *fileWriter) Write(ctx context.Context, msg *service.Message) error {
 	path, err := w.path.TryString(msg)
 	if err != nil {
@@ -135,13 +321,20 @@ func (w *fileWriter) Write(ctx context.Context, msg *service.Message) error {
 	defer w.handleMut.Unlock()
 
 	if w.handle != nil && path == w.handlePath {
-		return w.writeTo(w.handle, msg)
-	}
-	if w.handle != nil {
-		if err := w.handle.Close(); err != nil {
-			return err
+		if !w.ageExceeded() {
+			if err := w.writeTo(w.handle, msg); err != nil {
+				return err
+			}
+			w.rotateIfFull()
+			return nil
+		}
+		if err := w.rotate(); err != nil {
+			return fmt.Errorf("failed to rotate file: %w", err)
 		}
 	}
+	if err := w.closeHandle(); err != nil {
+		return err
+	}
 
 	flag := os.O_CREATE | os.O_RDWR
 	if w.appendMode {
@@ -166,6 +359,13 @@ func (w *fileWriter) Write(ctx context.Context, msg *service.Message) error {
 	}
 
 	w.handlePath = path
+	w.handleOpened = time.Now()
+	w.handleSize = 0
+	if w.appendMode {
+		if info, err := file.Stat(); err == nil {
+			w.handleSize = info.Size()
+		}
+	}
 	if err := w.writeTo(handle, msg); err != nil {
 		_ = handle.Close()
 		return err
@@ -173,20 +373,21 @@ func (w *fileWriter) Write(ctx context.Context, msg *service.Message) error {
 
 	if w.appendMode {
 		w.handle = handle
-	} else {
-		_ = handle.Close()
+		w.rotateIfFull()
+		return nil
 	}
-	return nil
+	if w.syncPolicy == fileSyncPolicyClose {
+		if err := syncFile(handle); err != nil {
+			_ = handle.Close()
+			return err
+		}
+	}
+	return handle.Close()
 }
 
 func (w *fileWriter) Close(ctx context.Context) error {
 	w.handleMut.Lock()
 	defer w.handleMut.Unlock()
 
-	var err error
-	if w.handle != nil {
-		err = w.handle.Close()
-		w.handle = nil
-	}
-	return err
+	return w.closeHandle()
 }
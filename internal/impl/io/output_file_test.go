@@ -0,0 +1,178 @@
+package io
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func newTestFileWriter(t *testing.T, confStr string, args ...any) *fileWriter {
+	t.Helper()
+
+	pConf, err := fileOutputSpec().ParseYAML(fmt.Sprintf(confStr, args...), nil)
+	require.NoError(t, err)
+
+	conf, err := fileOutputConfigFromParsed(pConf)
+	require.NoError(t, err)
+
+	w, err := newFileWriter(conf, service.MockResources())
+	require.NoError(t, err)
+	return w
+}
+
+func TestFileOutputInterpolatedPaths(t *testing.T) {
+	dir := t.TempDir()
+
+	w := newTestFileWriter(t, `
+path: '%v/${! meta("topic") }.txt'
+codec: lines
+`, dir)
+
+	ctx := context.Background()
+	for _, m := range []struct {
+		topic, content string
+	}{
+		{"foo", "first"},
+		{"foo", "second"},
+		{"bar", "third"},
+	} {
+		msg := service.NewMessage([]byte(m.content))
+		msg.MetaSetMut("topic", m.topic)
+		require.NoError(t, w.Write(ctx, msg))
+	}
+	require.NoError(t, w.Close(ctx))
+
+	b, err := os.ReadFile(filepath.Join(dir, "foo.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", string(b))
+
+	b, err = os.ReadFile(filepath.Join(dir, "bar.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(b))
+}
+
+func TestFileOutputRotationSize(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "data.txt")
+
+	w := newTestFileWriter(t, `
+path: '%v'
+codec: lines
+rotation:
+  max_size: 8
+sync_policy: write
+`, outPath)
+
+	ctx := context.Background()
+	for _, content := range []string{"hello", "world", "foo", "bar", "baz"} {
+		require.NoError(t, w.Write(ctx, service.NewMessage([]byte(content))))
+	}
+	require.NoError(t, w.Close(ctx))
+
+	rotated, err := filepath.Glob(outPath + ".*")
+	require.NoError(t, err)
+	require.Len(t, rotated, 2)
+	sort.Strings(rotated)
+
+	var contents []string
+	for _, p := range rotated {
+		b, err := os.ReadFile(p)
+		require.NoError(t, err)
+		contents = append(contents, string(b))
+	}
+	assert.Equal(t, []string{"hello\nworld\n", "foo\nbar\n"}, contents)
+
+	b, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, "baz\n", string(b))
+}
+
+func TestFileOutputRotationMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "data.txt")
+
+	w := newTestFileWriter(t, `
+path: '%v'
+codec: lines
+rotation:
+  max_age: 1h
+`, outPath)
+
+	ctx := context.Background()
+	for _, content := range []string{"hello", "world"} {
+		require.NoError(t, w.Write(ctx, service.NewMessage([]byte(content))))
+	}
+
+	// Age the open file beyond the limit so that the next write rotates it.
+	w.handleMut.Lock()
+	w.handleOpened = w.handleOpened.Add(-time.Hour)
+	w.handleMut.Unlock()
+
+	require.NoError(t, w.Write(ctx, service.NewMessage([]byte("foo"))))
+	require.NoError(t, w.Close(ctx))
+
+	rotated, err := filepath.Glob(outPath + ".*")
+	require.NoError(t, err)
+	require.Len(t, rotated, 1)
+
+	b, err := os.ReadFile(rotated[0])
+	require.NoError(t, err)
+	assert.Equal(t, "hello\nworld\n", string(b))
+
+	b, err = os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, "foo\n", string(b))
+}
+
+func TestFileOutputRotationCompress(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "data.txt")
+
+	w := newTestFileWriter(t, `
+path: '%v'
+codec: lines
+rotation:
+  max_size: 6
+  compress: true
+`, outPath)
+
+	ctx := context.Background()
+	for _, content := range []string{"hello", "foo"} {
+		require.NoError(t, w.Write(ctx, service.NewMessage([]byte(content))))
+	}
+	require.NoError(t, w.Close(ctx))
+
+	rotated, err := filepath.Glob(outPath + ".*.gz")
+	require.NoError(t, err)
+	require.Len(t, rotated, 1)
+
+	f, err := os.Open(rotated[0])
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+
+	zr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	b, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(b))
+
+	uncompressed, err := filepath.Glob(outPath + ".*[0-9]")
+	require.NoError(t, err)
+	assert.Empty(t, uncompressed)
+
+	b, err = os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, "foo\n", string(b))
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"errors"
-	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -42,6 +41,10 @@ const (
 	hsoFieldCORS               = "cors"
 	hsoFieldCORSEnabled        = "enabled"
 	hsoFieldCORSAllowedOrigins = "allowed_origins"
+	hsoFieldCORSAllowedMethods = "allowed_methods"
+	hsoFieldCORSAllowedHeaders = "allowed_headers"
+	hsoFieldCORSAllowCreds     = "allow_credentials"
+	hsoFieldBasicAuth          = "basic_auth"
 )
 
 type hsoConfig struct {
@@ -54,6 +57,7 @@ type hsoConfig struct {
 	CertFile     string
 	KeyFile      string
 	CORS         httpserver.CORSConfig
+	BasicAuth    httpserver.BasicAuthConfig
 }
 
 func hsoConfigFromParsed(pConf *service.ParsedConfig) (conf hsoConfig, err error) {
@@ -95,6 +99,9 @@ func hsoConfigFromParsed(pConf *service.ParsedConfig) (conf hsoConfig, err error
 	if conf.CORS, err = corsConfigFromParsed(pConf.Namespace(hsoFieldCORS)); err != nil {
 		return
 	}
+	if conf.BasicAuth, err = basicAuthConfigFromParsed(pConf.Namespace(hsoFieldBasicAuth)); err != nil {
+		return
+	}
 	return
 }
 
@@ -145,7 +152,9 @@ Please note, messages are considered delivered as soon as the data is written to
 				Advanced().
 				Default(""),
 			service.NewInternalField(corsSpec),
-		)
+			basicAuthFieldSpec(),
+		).
+		LintRule(basicAuthLintRule)
 }
 
 func init() {
@@ -210,8 +219,8 @@ func newHTTPServerOutput(conf hsoConfig, mgr bundle.NewManagement) (output.Strea
 	if conf.Address != "" {
 		gMux = mux.NewRouter()
 		server = &http.Server{Addr: conf.Address}
-		if server.Handler, err = conf.CORS.WrapHandler(gMux); err != nil {
-			return nil, fmt.Errorf("bad CORS configuration: %w", err)
+		if server.Handler, err = serverHandler(gMux, conf.CORS, conf.BasicAuth); err != nil {
+			return nil, err
 		}
 	}
 
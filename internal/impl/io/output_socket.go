@@ -2,6 +2,8 @@ package io
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"io"
 	"net"
 	"sync"
@@ -14,6 +16,7 @@ import (
 const (
 	osFieldNetwork = "network"
 	osFieldAddress = "address"
+	osFieldTLS     = "tls"
 )
 
 func socketOutputSpec() *service.ConfigSpec {
@@ -28,7 +31,11 @@ func socketOutputSpec() *service.ConfigSpec {
 				Description("The address to connect to.").
 				Examples("/tmp/benthos.sock", "127.0.0.1:6000"),
 			service.NewInternalField(codec.NewWriterDocs("codec").HasDefault("lines")),
-		)
+			service.NewTLSToggledField(osFieldTLS).
+				Description("Custom TLS settings can be used to override system defaults. TLS is only supported when the `network` is `tcp`.").
+				Version("4.39.0"),
+		).
+		LintRule(`root = if this.tls.enabled.or(false) && this.network != "tcp" { "tls can only be enabled with the network type tcp" }`)
 }
 
 func init() {
@@ -47,6 +54,8 @@ type socketWriter struct {
 	address    string
 	suffixFn   codec.SuffixFn
 	appendMode bool
+	tlsEnabled bool
+	tlsConf    *tls.Config
 
 	log *service.Logger
 
@@ -72,6 +81,13 @@ func newSocketWriterFromParsed(pConf *service.ParsedConfig, mgr *service.Resourc
 	if w.suffixFn, w.appendMode, err = codec.GetWriter(codecStr); err != nil {
 		return
 	}
+	if w.tlsConf, w.tlsEnabled, err = pConf.FieldTLSToggled(osFieldTLS); err != nil {
+		return
+	}
+	if w.tlsEnabled && w.network != "tcp" {
+		err = errors.New("tls can only be enabled with the network type tcp")
+		return
+	}
 	return
 }
 
@@ -83,6 +99,13 @@ func (s *socketWriter) Connect(ctx context.Context) error {
 	}
 
 	var err error
+	if s.tlsEnabled {
+		dialer := &tls.Dialer{Config: s.tlsConf}
+		if s.writer, err = dialer.DialContext(ctx, s.network, s.address); err != nil {
+			return err
+		}
+		return nil
+	}
 	if s.writer, err = net.Dial(s.network, s.address); err != nil {
 		return err
 	}
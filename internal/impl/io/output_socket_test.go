@@ -3,6 +3,7 @@ package io
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"path/filepath"
@@ -223,3 +224,72 @@ address: %v
 
 	conn.Close()
 }
+
+func TestTCPSocketTLS(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	cert, err := createSelfSignedCertificate()
+	require.NoError(t, err)
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	})
+	require.NoError(t, err)
+	defer ln.Close()
+
+	wtr := socketWriterFromConf(t, `
+network: tcp
+address: %v
+tls:
+  enabled: true
+  skip_cert_verify: true
+`, ln.Addr().String())
+
+	connErrChan := make(chan error, 1)
+	go func() {
+		connErrChan <- wtr.Connect(ctx)
+	}()
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var buf bytes.Buffer
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+		_, _ = buf.ReadFrom(conn)
+		wg.Done()
+	}()
+
+	select {
+	case err := <-connErrChan:
+		require.NoError(t, err)
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	require.NoError(t, wtr.Write(ctx, service.NewMessage([]byte("foo"))))
+	require.NoError(t, wtr.Write(ctx, service.NewMessage([]byte("bar"))))
+
+	require.NoError(t, wtr.Close(ctx))
+	wg.Wait()
+
+	require.Equal(t, "foo\nbar\n", buf.String())
+}
+
+func TestSocketTLSBadNetwork(t *testing.T) {
+	conf, err := socketOutputSpec().ParseYAML(`
+network: udp
+address: localhost:6000
+tls:
+  enabled: true
+`, nil)
+	require.NoError(t, err)
+
+	_, err = newSocketWriterFromParsed(conf, service.MockResources())
+	require.Error(t, err)
+}
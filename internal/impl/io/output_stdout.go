@@ -1,7 +1,10 @@
 package io
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 
@@ -9,13 +12,42 @@ import (
 	"github.com/redpanda-data/benthos/v4/public/service"
 )
 
+const (
+	stdoFieldFormat           = "format"
+	stdoFieldMetadataEnvelope = "metadata_envelope"
+
+	stdoutFormatRaw        = "raw"
+	stdoutFormatJSON       = "json"
+	stdoutFormatJSONPretty = "json_pretty"
+)
+
+func stdoutOutputSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Stable().
+		Categories("Local").
+		Summary(`Prints messages to stdout as a continuous stream of data.`).
+		Fields(
+			service.NewInternalField(codec.NewWriterDocs("codec").AtVersion("3.46.0").HasDefault("lines")),
+			service.NewStringAnnotatedEnumField(stdoFieldFormat, map[string]string{
+				stdoutFormatRaw:        "Messages are printed as their raw bytes.",
+				stdoutFormatJSON:       "Messages are parsed as JSON and printed in a compact form, which combined with the `lines` codec results in JSON lines.",
+				stdoutFormatJSONPretty: "Messages are parsed as JSON and printed in a pretty form with indentation.",
+			}).
+				Description("The format in which messages are printed. When a JSON format is used messages that are not valid JSON are printed as a JSON string.").
+				Advanced().
+				Default(stdoutFormatRaw).
+				Version("4.39.0"),
+			service.NewBoolField(stdoFieldMetadataEnvelope).
+				Description("Whether to print each message wrapped in a JSON object containing a `content` field and a `metadata` field with all of the metadata of the message. When the `format` is `raw` the content is printed as a string.").
+				Advanced().
+				Default(false).
+				Version("4.39.0"),
+		)
+}
+
 func init() {
 	err := service.RegisterOutput(
-		"stdout", service.NewConfigSpec().
-			Stable().
-			Categories("Local").
-			Summary(`Prints messages to stdout as a continuous stream of data.`).
-			Fields(service.NewInternalField(codec.NewWriterDocs("codec").AtVersion("3.46.0").HasDefault("lines"))),
+		"stdout", stdoutOutputSpec(),
 		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Output, int, error) {
 			w, err := newStdoutWriterFromParsed(conf)
 			if err != nil {
@@ -31,6 +63,9 @@ func init() {
 type stdoutWriter struct {
 	suffixFn codec.SuffixFn
 	handle   io.WriteCloser
+
+	format           string
+	metadataEnvelope bool
 }
 
 func newStdoutWriterFromParsed(conf *service.ParsedConfig) (*stdoutWriter, error) {
@@ -44,18 +79,80 @@ func newStdoutWriterFromParsed(conf *service.ParsedConfig) (*stdoutWriter, error
 		return nil, err
 	}
 
-	return &stdoutWriter{
+	w := &stdoutWriter{
 		suffixFn: codec,
 		handle:   os.Stdout,
-	}, nil
+	}
+	if w.format, err = conf.FieldString(stdoFieldFormat); err != nil {
+		return nil, err
+	}
+	switch w.format {
+	case stdoutFormatRaw, stdoutFormatJSON, stdoutFormatJSONPretty:
+	default:
+		return nil, fmt.Errorf("unrecognised format: %v", w.format)
+	}
+	if w.metadataEnvelope, err = conf.FieldBool(stdoFieldMetadataEnvelope); err != nil {
+		return nil, err
+	}
+	return w, nil
 }
 
 func (w *stdoutWriter) Connect(ctx context.Context) error {
 	return nil
 }
 
+func (w *stdoutWriter) formatBytes(p *service.Message) ([]byte, error) {
+	if w.format == stdoutFormatRaw && !w.metadataEnvelope {
+		return p.AsBytes()
+	}
+
+	var content any
+	if w.format == stdoutFormatRaw {
+		mBytes, err := p.AsBytes()
+		if err != nil {
+			return nil, err
+		}
+		content = string(mBytes)
+	} else {
+		var err error
+		if content, err = p.AsStructured(); err != nil {
+			// Messages that are not valid JSON are printed as a JSON string
+			// rather than rejected, as they would otherwise be retried
+			// indefinitely.
+			mBytes, bErr := p.AsBytes()
+			if bErr != nil {
+				return nil, bErr
+			}
+			content = string(mBytes)
+		}
+	}
+
+	if w.metadataEnvelope {
+		meta := map[string]any{}
+		_ = p.MetaWalkMut(func(k string, v any) error {
+			meta[k] = v
+			return nil
+		})
+		content = map[string]any{
+			"content":  content,
+			"metadata": meta,
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if w.format == stdoutFormatJSONPretty {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(content); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
 func (w *stdoutWriter) writeTo(wtr io.Writer, p *service.Message) error {
-	mBytes, err := p.AsBytes()
+	mBytes, err := w.formatBytes(p)
 	if err != nil {
 		return err
 	}
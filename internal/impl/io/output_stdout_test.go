@@ -0,0 +1,103 @@
+package io
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (n nopWriteCloser) Close() error {
+	return nil
+}
+
+func TestStdoutOutputFormats(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		input  string
+		output string
+	}{
+		{
+			name:   "raw",
+			config: `{}`,
+			output: "{\"foo\": \"bar\"}\n",
+		},
+		{
+			name:   "raw custom delim",
+			config: `codec: 'delim:|'`,
+			output: "{\"foo\": \"bar\"}|",
+		},
+		{
+			name:   "json",
+			config: `format: json`,
+			output: "{\"foo\":\"bar\"}\n",
+		},
+		{
+			name:   "json pretty",
+			config: `format: json_pretty`,
+			output: "{\n  \"foo\": \"bar\"\n}\n",
+		},
+		{
+			name: "raw envelope",
+			config: `
+metadata_envelope: true
+`,
+			output: "{\"content\":\"{\\\"foo\\\": \\\"bar\\\"}\",\"metadata\":{\"baz\":\"buz\"}}\n",
+		},
+		{
+			name: "json envelope",
+			config: `
+format: json
+metadata_envelope: true
+`,
+			output: "{\"content\":{\"foo\":\"bar\"},\"metadata\":{\"baz\":\"buz\"}}\n",
+		},
+		{
+			name:   "json invalid",
+			config: `format: json`,
+			input:  `not <json>`,
+			output: "\"not <json>\"\n",
+		},
+		{
+			name:   "json no html escape",
+			config: `format: json`,
+			input:  `{"foo":"<a & b>"}`,
+			output: "{\"foo\":\"<a & b>\"}\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			conf, err := stdoutOutputSpec().ParseYAML(test.config, nil)
+			require.NoError(t, err)
+
+			w, err := newStdoutWriterFromParsed(conf)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			w.handle = nopWriteCloser{Writer: &buf}
+
+			input := test.input
+			if input == "" {
+				input = `{"foo": "bar"}`
+			}
+
+			msg := service.NewMessage([]byte(input))
+			msg.MetaSetMut("baz", "buz")
+			require.NoError(t, w.Write(context.Background(), msg))
+
+			assert.Equal(t, test.output, buf.String())
+		})
+	}
+}
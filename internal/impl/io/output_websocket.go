@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"io/fs"
 	"net/http"
 	"net/url"
@@ -25,8 +26,19 @@ func websocketOutputSpec() *service.ConfigSpec {
 		Stable().
 		Categories("Network").
 		Summary("Sends messages to an HTTP server via a websocket connection.").
+		Description(`If the connection is lost then any messages that failed to be written are rejected and the output attempts to reconnect with an exponential backoff, at which point the rejected messages are reattempted.
+
+It is possible to configure an ` + "`open_message`" + `, which when set to a non-empty string will be sent to the websocket server each time a connection is established, before any messages are written.`).
 		Field(service.NewURLField("url").Description("The URL to connect to.")).
 		Field(service.NewURLField("proxy_url").Description("An optional HTTP proxy URL.").Advanced().Optional()).
+		Field(service.NewStringField("open_message").
+			Description("An optional message to send to the server upon connection.").
+			Advanced().Optional().Version("4.39.0")).
+		Field(service.NewStringAnnotatedEnumField("open_message_type", map[string]string{
+			string(wsOpenMsgTypeBinary): "Binary data open_message.",
+			string(wsOpenMsgTypeText):   "Text data open_message. The text message payload is interpreted as UTF-8 encoded text data.",
+		}).Description("An optional flag to indicate the data type of open_message.").
+			Advanced().Default(string(wsOpenMsgTypeBinary)).Version("4.39.0")).
 		Field(service.NewTLSToggledField("tls"))
 
 	for _, f := range service.NewHTTPRequestAuthSignerFields() {
@@ -71,6 +83,8 @@ type websocketWriter struct {
 	tlsEnabled     bool
 	tlsConf        *tls.Config
 	reqSigner      func(f fs.FS, req *http.Request) error
+	openMsgType    wsOpenMsgType
+	openMsg        []byte
 }
 
 func newWebsocketWriterFromParsed(conf *service.ParsedConfig, mgr bundle.NewManagement) (*websocketWriter, error) {
@@ -98,6 +112,15 @@ func newWebsocketWriterFromParsed(conf *service.ParsedConfig, mgr bundle.NewMana
 	if ws.reqSigner, err = conf.HTTPRequestAuthSignerFromParsed(); err != nil {
 		return nil, err
 	}
+
+	var openMsgStr, openMsgTypeStr string
+	if openMsgTypeStr, err = conf.FieldString("open_message_type"); err != nil {
+		return nil, err
+	}
+	ws.openMsgType = wsOpenMsgType(openMsgTypeStr)
+	if openMsgStr, _ = conf.FieldString("open_message"); openMsgStr != "" {
+		ws.openMsg = []byte(openMsgStr)
+	}
 	return ws, nil
 }
 
@@ -151,10 +174,36 @@ func (w *websocketWriter) Connect(ctx context.Context) error {
 		return err
 	}
 
+	var openMsgType int
+	switch w.openMsgType {
+	case wsOpenMsgTypeBinary:
+		openMsgType = websocket.BinaryMessage
+	case wsOpenMsgTypeText:
+		openMsgType = websocket.TextMessage
+	default:
+		client.Close()
+		return fmt.Errorf("unrecognised open_message_type: %s", w.openMsgType)
+	}
+
+	if len(w.openMsg) > 0 {
+		if err := client.WriteMessage(openMsgType, w.openMsg); err != nil {
+			client.Close()
+			return err
+		}
+	}
+
 	go func(c *websocket.Conn) {
 		for {
 			if _, _, cerr := c.NextReader(); cerr != nil {
 				c.Close()
+
+				// Drop our reference to the connection so that the next write
+				// triggers a reconnect rather than failing.
+				w.lock.Lock()
+				if w.client == c {
+					w.client = nil
+				}
+				w.lock.Unlock()
 				break
 			}
 		}
@@ -175,8 +224,11 @@ func (w *websocketWriter) WriteBatch(ctx context.Context, msg message.Batch) err
 	})
 	if err != nil {
 		w.lock.Lock()
-		w.client = nil
+		if w.client == client {
+			w.client = nil
+		}
 		w.lock.Unlock()
+		_ = client.Close()
 		if errors.Is(err, websocket.ErrCloseSent) {
 			return component.ErrNotConnected
 		}
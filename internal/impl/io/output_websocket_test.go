@@ -2,6 +2,7 @@ package io
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/redpanda-data/benthos/v4/internal/manager/mock"
@@ -105,3 +107,66 @@ websocket:
 	m.TriggerCloseNow()
 	require.NoError(t, m.WaitForClose(ctx))
 }
+
+func TestWebsocketOutputOpenMessageReconnect(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	received := make(chan []string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{}
+
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		// Read the open message and a single message before hanging up.
+		var msgs []string
+		for i := 0; i < 2; i++ {
+			_, msgBytes, err := ws.ReadMessage()
+			if err != nil {
+				t.Error(err)
+				break
+			}
+			msgs = append(msgs, string(msgBytes))
+		}
+		_ = ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		ws.Close()
+		received <- msgs
+	}))
+
+	wsURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	wsURL.Scheme = "ws"
+
+	pConf, err := websocketOutputSpec().ParseYAML(fmt.Sprintf(`
+url: %v
+open_message: hello
+open_message_type: text
+`, wsURL.String()), nil)
+	require.NoError(t, err)
+
+	w, err := newWebsocketWriterFromParsed(pConf, mock.NewManager())
+	require.NoError(t, err)
+
+	for _, msg := range []string{"foo", "bar"} {
+		require.NoError(t, w.Connect(ctx))
+		require.NoError(t, w.WriteBatch(ctx, message.QuickBatch([][]byte{[]byte(msg)})))
+		select {
+		case msgs := <-received:
+			require.Equal(t, []string{"hello", msg}, msgs)
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		}
+
+		// The writer should drop the connection as soon as the server hangs
+		// up so that the next write triggers a reconnect.
+		assert.Eventually(t, func() bool {
+			return w.getWS() == nil
+		}, time.Second*5, time.Millisecond*10)
+	}
+
+	require.NoError(t, w.Close(ctx))
+}
@@ -38,6 +38,12 @@ This buffer is appropriate when consuming messages from inputs that do not grace
 
 This buffer has a configurable limit, where consumption will be stopped with back pressure upstream if the total size of messages in the buffer reaches this amount. Since this calculation is only an estimate, and the real size of messages in RAM is always higher, it is recommended to set the limit significantly below the amount of RAM available.
 
+== Metrics
+
+This buffer emits the gauges ` + "`buffer_memory_bytes`" + ` and ` + "`buffer_memory_fill_percent`" + ` tracking the estimated size of messages currently held in the buffer in bytes and as a percentage of the limit, and ` + "`buffer_memory_blocked_writes`" + ` tracking the number of writes currently blocked by back pressure.
+
+If messages must survive a restart or the buffer needs to grow beyond available memory then consider using the ` + "xref:components:buffers/disk.adoc[`disk` buffer]" + ` instead.
+
 == Delivery guarantees
 
 This buffer intentionally weakens the delivery guarantees of the pipeline and therefore should never be used in places where data loss is unacceptable.
@@ -88,7 +94,11 @@ func newMemoryBufferFromConfig(conf *service.ParsedConfig, res *service.Resource
 		}
 	}
 
-	return newMemoryBuffer(limit, batcher), nil
+	m := newMemoryBuffer(limit, batcher)
+	m.mBytes = res.Metrics().NewGauge("buffer_memory_bytes")
+	m.mFillPercent = res.Metrics().NewGauge("buffer_memory_fill_percent")
+	m.mBlockedWrites = res.Metrics().NewGauge("buffer_memory_blocked_writes")
+	return m, nil
 }
 
 //------------------------------------------------------------------------------
@@ -108,6 +118,11 @@ type memoryBuffer struct {
 	closed     bool
 
 	batcher *service.Batcher
+
+	blockedWrites  int64
+	mBytes         *service.MetricGauge
+	mFillPercent   *service.MetricGauge
+	mBlockedWrites *service.MetricGauge
 }
 
 func newMemoryBuffer(capacity int, batcher *service.Batcher) *memoryBuffer {
@@ -118,6 +133,15 @@ func newMemoryBuffer(capacity int, batcher *service.Batcher) *memoryBuffer {
 	}
 }
 
+// updateMetrics must be called while holding the lock.
+func (m *memoryBuffer) updateMetrics() {
+	m.mBytes.Set(int64(m.bytes))
+	if m.cap > 0 {
+		m.mFillPercent.Set(int64(m.bytes) * 100 / int64(m.cap))
+	}
+	m.mBlockedWrites.Set(m.blockedWrites)
+}
+
 //------------------------------------------------------------------------------
 
 func (m *memoryBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
@@ -213,6 +237,7 @@ func (m *memoryBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, ser
 		defer m.cond.L.Unlock()
 		if err == nil {
 			m.bytes -= outSize
+			m.updateMetrics()
 		} else {
 			m.batches = append(batchSources, m.batches...)
 		}
@@ -249,6 +274,14 @@ func (m *memoryBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageB
 		return component.ErrTypeClosed
 	}
 
+	if (m.bytes + extraBytes) > m.cap {
+		m.blockedWrites++
+		m.updateMetrics()
+		defer func() {
+			m.blockedWrites--
+			m.updateMetrics()
+		}()
+	}
 	for (m.bytes + extraBytes) > m.cap {
 		m.cond.Wait()
 		if m.closed {
@@ -261,6 +294,7 @@ func (m *memoryBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageB
 		size: extraBytes,
 	})
 	m.bytes += extraBytes
+	m.updateMetrics()
 
 	m.cond.Broadcast()
 	return nil
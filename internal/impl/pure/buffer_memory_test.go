@@ -13,7 +13,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/redpanda-data/benthos/v4/internal/bundle"
 	"github.com/redpanda-data/benthos/v4/internal/component"
+	"github.com/redpanda-data/benthos/v4/internal/component/metrics"
+	"github.com/redpanda-data/benthos/v4/internal/component/testutil"
+	"github.com/redpanda-data/benthos/v4/internal/manager/mock"
+	"github.com/redpanda-data/benthos/v4/internal/message"
 	"github.com/redpanda-data/benthos/v4/public/service"
 )
 
@@ -476,3 +481,60 @@ batch_policy:
 	msgEqual(t, "hello", m[0])
 	require.NoError(t, ackFunc(ctx, nil))
 }
+
+func TestMemoryMetrics(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	conf, err := testutil.BufferFromYAML(`
+memory:
+  limit: 20
+`)
+	require.NoError(t, err)
+
+	mockMetrics := metrics.NewLocal()
+
+	mgr := mock.NewManager()
+	mgr.M = mockMetrics
+
+	buf, err := bundle.AllBuffers.Init(conf, mgr)
+	require.NoError(t, err)
+	defer func() {
+		buf.TriggerCloseNow()
+		assert.NoError(t, buf.WaitForClose(tCtx))
+	}()
+
+	tChan := make(chan message.Transaction)
+	require.NoError(t, buf.Consume(tChan))
+
+	resChan := make(chan error)
+	select {
+	case tChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("0123456789")}), resChan):
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+	select {
+	case err := <-resChan:
+		require.NoError(t, err)
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+
+	assert.Eventually(t, func() bool {
+		counters := mockMetrics.GetCounters()
+		return counters["buffer_memory_bytes"] == 10 && counters["buffer_memory_fill_percent"] == 50
+	}, time.Second, time.Millisecond*10)
+
+	var tran message.Transaction
+	select {
+	case tran = <-buf.TransactionChan():
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+	require.NoError(t, tran.Ack(tCtx, nil))
+
+	assert.Eventually(t, func() bool {
+		counters := mockMetrics.GetCounters()
+		return counters["buffer_memory_bytes"] == 0 && counters["buffer_memory_fill_percent"] == 0
+	}, time.Second, time.Millisecond*10)
+}
@@ -40,6 +40,31 @@ output:
   # Processors applied to messages sent to all brokered outputs.
   processors:
     - resource: general_processor
+`+"```"+`
+
+== Batching
+
+The `+"`batching`"+` field of a broker applies a batching policy to messages before they are routed to any of its outputs. In order to batch messages differently for individual outputs you can instead wrap each of those outputs in a broker of its own with a single output and a batching policy:
+
+`+"```yaml"+`
+output:
+  broker:
+    pattern: fan_out
+    outputs:
+      # Messages sent to this output are grouped into large batches.
+      - broker:
+          outputs:
+            - file:
+                path: ./archives/${! timestamp_unix_nano() }.jsonl
+          batching:
+            count: 1000
+            period: 1m
+            processors:
+              - archive:
+                  format: lines
+
+      # Messages sent to this output are sent individually.
+      - resource: foo
 `+"```"+``).
 		Footnotes(`
 == Patterns
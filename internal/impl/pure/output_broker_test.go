@@ -100,6 +100,82 @@ broker:
 	}
 }
 
+func TestFanOutBrokerPerOutputBatching(t *testing.T) {
+	dir := t.TempDir()
+
+	conf, err := testutil.OutputFromYAML(strings.ReplaceAll(`
+broker:
+  pattern: fan_out
+  outputs:
+    - broker:
+        outputs:
+          - file:
+              path: '$DIR/batched/${!count("perOutputBatched")}.txt'
+              codec: all-bytes
+        batching:
+          count: 2
+          processors:
+            - archive:
+                format: lines
+    - file:
+        path: '$DIR/single/${!count("perOutputSingle")}.txt'
+        codec: all-bytes
+`, "$DIR", dir))
+	require.NoError(t, err)
+
+	s, err := mock.NewManager().NewOutput(conf)
+	require.NoError(t, err)
+
+	sendChan := make(chan message.Transaction)
+	require.NoError(t, s.Consume(sendChan))
+
+	defer func() {
+		s.TriggerCloseNow()
+
+		ctx, done := context.WithTimeout(context.Background(), time.Second*10)
+		assert.NoError(t, s.WaitForClose(ctx))
+		done()
+	}()
+
+	inputs := []string{
+		"first", "second", "third", "fourth",
+	}
+
+	var resChans []chan error
+	for _, input := range inputs {
+		resChan := make(chan error, 1)
+		resChans = append(resChans, resChan)
+		select {
+		case sendChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte(input)}), resChan):
+		case <-time.After(time.Second):
+			t.Fatal("Action timed out")
+		}
+	}
+
+	for _, resChan := range resChans {
+		select {
+		case res := <-resChan:
+			require.NoError(t, res)
+		case <-time.After(time.Second):
+			t.Fatal("Action timed out")
+		}
+	}
+
+	expFiles := map[string]string{
+		"./batched/1.txt": "first\nsecond",
+		"./batched/2.txt": "third\nfourth",
+		"./single/1.txt":  "first",
+		"./single/2.txt":  "second",
+		"./single/3.txt":  "third",
+		"./single/4.txt":  "fourth",
+	}
+	for k, exp := range expFiles {
+		fileBytes, err := os.ReadFile(filepath.Join(dir, k))
+		require.NoError(t, err, k)
+		assert.Equal(t, exp, string(fileBytes), k)
+	}
+}
+
 func TestRoundRobinBroker(t *testing.T) {
 	dir := t.TempDir()
 
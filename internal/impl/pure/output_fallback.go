@@ -3,10 +3,13 @@ package pure
 import (
 	"context"
 	"errors"
+	"strconv"
 
 	"github.com/Jeffail/shutdown"
 
 	"github.com/redpanda-data/benthos/v4/internal/batch"
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/bundle"
 	"github.com/redpanda-data/benthos/v4/internal/component"
 	"github.com/redpanda-data/benthos/v4/internal/component/interop"
 	"github.com/redpanda-data/benthos/v4/internal/component/output"
@@ -45,6 +48,29 @@ output:
 
 When a given output fails the message routed to the following output will have a metadata value named `+"`fallback_error`"+` containing a string error message outlining the cause of the failure. The content of this string will depend on the particular output and can be used to enrich the message or provide information used to broker the data to an appropriate output using something like a `+"`switch`"+` output.
 
+The message will also have a metadata value named `+"`fallback_error_path`"+` containing the component path of the output that failed, e.g. `+"`root.output.fallback.0`"+`.
+
+== Dead Letter Queues
+
+Combining a fallback output with a `+"xref:components:outputs/retry.adoc[`retry`]"+` output allows you to attempt a primary output a limited number of times before routing messages to a dead letter queue, rather than blocking the pipeline indefinitely:
+
+`+"```yaml"+`
+output:
+  fallback:
+    - retry:
+        max_retries: 5
+        output:
+          http_client:
+            url: http://foo:4195/post
+    - file:
+        path: /usr/local/benthos/dlq.jsonl
+      processors:
+        - mapping: |
+            root.content = content().string()
+            root.error = @fallback_error
+            root.path = @fallback_error_path
+`+"```"+`
+
 == Batching
 
 When an output within a fallback sequence uses batching, like so:
@@ -70,7 +96,7 @@ However, depending on the output and the error returned it is sometimes not poss
 			Field(service.NewOutputListField("").Default([]any{})),
 		func(conf *service.ParsedConfig, mgr *service.Resources) (out service.BatchOutput, batchPolicy service.BatchPolicy, maxInFlight int, err error) {
 			var w *fallbackBroker
-			if w, err = newFallbackFromParsed(conf); err != nil {
+			if w, err = newFallbackFromParsed(conf, interop.UnwrapManagement(mgr)); err != nil {
 				return
 			}
 
@@ -84,7 +110,7 @@ However, depending on the output and the error returned it is sometimes not poss
 
 //------------------------------------------------------------------------------
 
-func newFallbackFromParsed(conf *service.ParsedConfig) (*fallbackBroker, error) {
+func newFallbackFromParsed(conf *service.ParsedConfig, mgr bundle.NewManagement) (*fallbackBroker, error) {
 	pOutputs, err := conf.FieldOutputList()
 	if err != nil {
 		return nil, err
@@ -102,6 +128,11 @@ func newFallbackFromParsed(conf *service.ParsedConfig) (*fallbackBroker, error)
 	if t, err = newFallbackBroker(outputs); err != nil {
 		return nil, err
 	}
+
+	t.outputPaths = make([]string, len(outputs))
+	for i := range t.outputPaths {
+		t.outputPaths[i] = "root." + query.SliceToDotPath(append(mgr.Path(), "fallback", strconv.Itoa(i))...)
+	}
 	return t, nil
 }
 
@@ -110,6 +141,7 @@ type fallbackBroker struct {
 
 	outputTSChans []chan message.Transaction
 	outputs       []output.Streamed
+	outputPaths   []string
 
 	shutSig *shutdown.Signaller
 }
@@ -179,12 +211,19 @@ func (t *fallbackBroker) loop() {
 		}
 
 		outSorter, outBatch := message.NewSortGroup(tran.Payload)
-		nextBatchFromErr := func(err error) message.Batch {
+		nextBatchFromErr := func(failedIndex int, err error) message.Batch {
+			setErrMeta := func(p *message.Part, err error) {
+				p.MetaSetMut("fallback_error", err.Error())
+				if failedIndex < len(t.outputPaths) {
+					p.MetaSetMut("fallback_error_path", t.outputPaths[failedIndex])
+				}
+			}
+
 			var bErr *batch.Error
 			if len(outBatch) <= 1 || !errors.As(err, &bErr) {
 				tmpBatch := outBatch.ShallowCopy()
 				for _, m := range tmpBatch {
-					m.MetaSetMut("fallback_error", err.Error())
+					setErrMeta(m, err)
 				}
 				return tmpBatch
 			}
@@ -198,7 +237,7 @@ func (t *fallbackBroker) loop() {
 					}
 					seenIndexes[i] = struct{}{}
 					tmp := p.ShallowCopy()
-					tmp.MetaSetMut("fallback_error", err.Error())
+					setErrMeta(tmp, err)
 					onlyErrs = append(onlyErrs, tmp)
 				}
 				return true
@@ -210,7 +249,7 @@ func (t *fallbackBroker) loop() {
 			if len(onlyErrs) == 0 {
 				tmpBatch := outBatch.ShallowCopy()
 				for _, m := range tmpBatch {
-					m.MetaSetMut("fallback_error", err.Error())
+					setErrMeta(m, err)
 				}
 				return tmpBatch
 			}
@@ -228,7 +267,7 @@ func (t *fallbackBroker) loop() {
 			}
 
 			select {
-			case t.outputTSChans[i] <- message.NewTransactionFunc(nextBatchFromErr(err), ackFn):
+			case t.outputTSChans[i] <- message.NewTransactionFunc(nextBatchFromErr(i-1, err), ackFn):
 			case <-ctx.Done():
 				return ctx.Err()
 			}
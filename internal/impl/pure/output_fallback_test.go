@@ -33,6 +33,52 @@ func parseYAMLOutputConf(t testing.TB, formatStr string, args ...any) (conf outp
 	return
 }
 
+func TestFallbackOutputErrorPath(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "dlq.txt")
+
+	conf := parseYAMLOutputConf(t, `
+fallback:
+  - reject: 'nope'
+  - file:
+      path: '%v'
+      codec: lines
+    processors:
+      - mapping: 'root = @fallback_error_path + ": " + @fallback_error'
+`, outPath)
+
+	s, err := bundle.AllOutputs.Init(conf, mock.NewManager())
+	require.NoError(t, err)
+
+	sendChan := make(chan message.Transaction)
+	resChan := make(chan error)
+	require.NoError(t, s.Consume(sendChan))
+
+	t.Cleanup(func() {
+		ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+		s.TriggerCloseNow()
+		require.NoError(t, s.WaitForClose(ctx))
+		done()
+	})
+
+	select {
+	case sendChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("hello world")}), resChan):
+	case <-time.After(time.Second * 2):
+		t.Fatal("Action timed out")
+	}
+
+	select {
+	case res := <-resChan:
+		require.NoError(t, res)
+	case <-time.After(time.Second * 2):
+		t.Fatal("Action timed out")
+	}
+
+	fileBytes, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, "root.fallback.0: nope\n", string(fileBytes))
+}
+
 func TestFallbackOutputBasic(t *testing.T) {
 	dir := t.TempDir()
 
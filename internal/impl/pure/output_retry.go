@@ -119,17 +119,19 @@ type indefiniteRetry struct {
 func (r *indefiniteRetry) loop() {
 	wg := sync.WaitGroup{}
 
+	// The context used for acknowledgements must outlive any pending
+	// reattempts, which are waited on during a graceful shut down.
+	cnCtx, cnDone := r.shutSig.HardStopCtx(context.Background())
+
 	defer func() {
 		wg.Wait()
+		cnDone()
 		close(r.transactionsOut)
 		r.wrapped.TriggerCloseNow()
 		_ = r.wrapped.WaitForClose(context.Background())
 		r.shutSig.TriggerHasStopped()
 	}()
 
-	cnCtx, cnDone := r.shutSig.HardStopCtx(context.Background())
-	defer cnDone()
-
 	errInterruptChan := make(chan struct{})
 	var errLooped int64
 
@@ -19,6 +19,7 @@ import (
 	"github.com/redpanda-data/benthos/v4/internal/component/output"
 	"github.com/redpanda-data/benthos/v4/internal/log"
 	"github.com/redpanda-data/benthos/v4/internal/message"
+	"github.com/redpanda-data/benthos/v4/internal/retries"
 	"github.com/redpanda-data/benthos/v4/public/service"
 )
 
@@ -29,6 +30,8 @@ const (
 	soFieldCasesCheck        = "check"
 	soFieldCasesContinue     = "continue"
 	soFieldCasesOutput       = "output"
+	soFieldCasesRetry        = "retry"
+	soFieldCasesRetryEnabled = "enabled"
 )
 
 func switchOutputSpec() *service.ConfigSpec {
@@ -103,7 +106,9 @@ output:
 				Description(`
 If a selected output fails to send a message this field determines whether it is reattempted indefinitely. If set to false the error is instead propagated back to the input level.
 
-If a message can be routed to >1 outputs it is usually best to set this to true in order to avoid duplicate messages being routed to an output.`).
+If a message can be routed to >1 outputs it is usually best to set this to true in order to avoid duplicate messages being routed to an output.
+
+This behaviour can be overridden for individual cases with their own `+"`retry`"+` field.`).
 				Default(false),
 			service.NewBoolField(soFieldStrictMode).
 				Description(`This field determines whether an error should be reported if no condition is met. If set to true, an error is propagated back to the input level. The default behavior is false, which will drop the message.`).
@@ -123,6 +128,15 @@ If a message can be routed to >1 outputs it is usually best to set this to true
 					Description("Indicates whether, if this case passes for a message, the next case should also be tested.").
 					Default(false).
 					Advanced(),
+				service.NewObjectField(soFieldCasesRetry, append([]*service.ConfigField{
+					service.NewBoolField(soFieldCasesRetryEnabled).
+						Description("Whether this case should use its own retry policy.").
+						Default(false),
+				}, retries.CommonRetryBackOffFields(0, "500ms", "3s", "0s")...)...).
+					Description("An optional retry policy for this case. When enabled, messages that fail to be sent to the case output are reattempted using the given backoff, and only once retries are exhausted is the error propagated back to the input level. This takes precedence over the `"+soFieldRetryUntilSuccess+"` field for this case.").
+					Optional().
+					Advanced().
+					Version("4.39.0"),
 			).
 				Description("A list of switch cases, outlining outputs that can be routed to.").
 				Example([]any{
@@ -236,7 +250,19 @@ func switchOutputFromParsed(conf *service.ParsedConfig, mgr bundle.NewManagement
 		o.outputs[i] = interop.UnwrapOwnedOutput(w)
 
 		oMgr := mgr.IntoPath("switch", strconv.Itoa(i), "output")
-		if retryUntilSuccess {
+		caseRetry, err := cConf.FieldBool(soFieldCasesRetry, soFieldCasesRetryEnabled)
+		if err != nil {
+			return nil, err
+		}
+		if caseRetry {
+			boffCtor, err := retries.CommonRetryBackOffCtorFromParsed(cConf.Namespace(soFieldCasesRetry))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse case '%v' retry: %v", i, err)
+			}
+			if o.outputs[i], err = newIndefiniteRetry(oMgr, boffCtor, o.outputs[i]); err != nil {
+				return nil, fmt.Errorf("failed to create case '%v' output: %v", i, err)
+			}
+		} else if retryUntilSuccess {
 			if o.outputs[i], err = RetryOutputIndefinitely(oMgr, o.outputs[i]); err != nil {
 				return nil, fmt.Errorf("failed to create case '%v' output: %v", i, err)
 			}
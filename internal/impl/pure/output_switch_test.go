@@ -1135,3 +1135,63 @@ metrics:
 
 	assert.Subset(t, labels, []string{"root.output.switch.cases.0.output", "root.output.switch.cases.1.output"})
 }
+
+func TestSwitchCaseRetry(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	pConf, err := switchOutputSpec().ParseYAML(`
+cases:
+  - check: this.id == "a"
+    output:
+      reject: "nope"
+    retry:
+      enabled: true
+      max_retries: 2
+      backoff:
+        initial_interval: 1ms
+        max_interval: 1ms
+  - output:
+      drop: {}
+`, nil)
+	require.NoError(t, err)
+
+	s, err := switchOutputFromParsed(pConf, mock.NewManager())
+	require.NoError(t, err)
+
+	_, isRetry := s.outputs[0].(*indefiniteRetry)
+	assert.True(t, isRetry)
+	_, isRetry = s.outputs[1].(*indefiniteRetry)
+	assert.False(t, isRetry)
+
+	readChan := make(chan message.Transaction)
+	require.NoError(t, s.Consume(readChan))
+
+	for _, test := range []struct {
+		content string
+		errs    bool
+	}{
+		{content: `{"id":"a"}`, errs: true},
+		{content: `{"id":"b"}`, errs: false},
+	} {
+		resChan := make(chan error, 1)
+		select {
+		case readChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte(test.content)}), resChan):
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		}
+		select {
+		case err := <-resChan:
+			if test.errs {
+				assert.Error(t, err, test.content)
+			} else {
+				assert.NoError(t, err, test.content)
+			}
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		}
+	}
+
+	s.TriggerCloseNow()
+	require.NoError(t, s.WaitForClose(ctx))
+}
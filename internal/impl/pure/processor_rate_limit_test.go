@@ -26,7 +26,7 @@ func TestRateLimitBasic(t *testing.T) {
 	}
 
 	mgr := mock.NewManager()
-	mgr.RateLimits["foo"] = rlFn
+	mgr.RateLimits["foo"] = mock.RateLimit(rlFn)
 
 	conf, err := testutil.ProcessorFromYAML(`
 rate_limit:
@@ -69,7 +69,7 @@ func TestRateLimitErroredOut(t *testing.T) {
 	}
 
 	mgr := mock.NewManager()
-	mgr.RateLimits["foo"] = rlFn
+	mgr.RateLimits["foo"] = mock.RateLimit(rlFn)
 
 	conf, err := testutil.ProcessorFromYAML(`
 rate_limit:
@@ -122,7 +122,7 @@ func TestRateLimitBlocked(t *testing.T) {
 	}
 
 	mgr := mock.NewManager()
-	mgr.RateLimits["foo"] = rlFn
+	mgr.RateLimits["foo"] = mock.RateLimit(rlFn)
 
 	conf, err := testutil.ProcessorFromYAML(`
 rate_limit:
@@ -0,0 +1,225 @@
+package pure
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	arlFieldCount            = "count"
+	arlFieldMinCount         = "min_count"
+	arlFieldMaxCount         = "max_count"
+	arlFieldInterval         = "interval"
+	arlFieldIncrease         = "increase"
+	arlFieldDecreaseFactor   = "decrease_factor"
+	arlFieldLatencyThreshold = "latency_threshold"
+)
+
+func adaptiveRatelimitConfig() *service.ConfigSpec {
+	spec := service.NewConfigSpec().
+		Beta().
+		Version("4.39.0").
+		Summary(`An X every Y type rate limit where X is adjusted automatically based on feedback from the components that use it, following an additive increase/multiplicative decrease (AIMD) strategy.`).
+		Description(`
+Components that make requests to a rate limited resource, such as the ` + "`http_client`" + ` output and the ` + "`http`" + ` processor, report the outcome of each request to the rate limit. When a request is throttled, which is when it fails, returns a 429 or 5xx status code, or takes longer than ` + "`latency_threshold`" + `, the allowance is multiplied by the ` + "`decrease_factor`" + `. Otherwise, for each interval where requests succeeded without being throttled, the allowance is increased by ` + "`increase`" + `. The allowance is decreased at most once per interval, and always remains between ` + "`min_count`" + ` and ` + "`max_count`" + `.
+
+Components that do not report feedback leave the allowance unchanged, in which case this rate limit behaves like the ` + "`local`" + ` rate limit.
+
+Like the ` + "`local`" + ` rate limit this rate limit can be shared across any number of components within the pipeline but does not support distributed rate limits across multiple running instances.`).
+		Field(service.NewIntField(arlFieldCount).
+			Description("The initial number of requests to allow for a given period of time.").
+			Default(100)).
+		Field(service.NewIntField(arlFieldMinCount).
+			Description("The minimum number of requests to allow for a given period of time.").
+			Default(1)).
+		Field(service.NewIntField(arlFieldMaxCount).
+			Description("The maximum number of requests to allow for a given period of time.").
+			Default(1000)).
+		Field(service.NewDurationField(arlFieldInterval).
+			Description("The time window to limit requests by.").
+			Default("1s")).
+		Field(service.NewIntField(arlFieldIncrease).
+			Description("The number of requests to add to the allowance after each interval in which requests succeeded without being throttled.").
+			Default(1).
+			Advanced()).
+		Field(service.NewFloatField(arlFieldDecreaseFactor).
+			Description("The factor by which the allowance is multiplied when a request is throttled, must be greater than zero and less than one.").
+			Default(0.5).
+			Advanced()).
+		Field(service.NewDurationField(arlFieldLatencyThreshold).
+			Description("An optional latency above which a request is considered throttled. This field can be set to an empty string in order to only consider failed requests as throttled.").
+			Default("").
+			Example("500ms").
+			Advanced())
+
+	return spec
+}
+
+func init() {
+	err := service.RegisterRateLimit(
+		"adaptive", adaptiveRatelimitConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.RateLimit, error) {
+			return newAdaptiveRatelimitFromConfig(conf)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func newAdaptiveRatelimitFromConfig(conf *service.ParsedConfig) (*adaptiveRatelimit, error) {
+	count, err := conf.FieldInt(arlFieldCount)
+	if err != nil {
+		return nil, err
+	}
+	minCount, err := conf.FieldInt(arlFieldMinCount)
+	if err != nil {
+		return nil, err
+	}
+	maxCount, err := conf.FieldInt(arlFieldMaxCount)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := conf.FieldDuration(arlFieldInterval)
+	if err != nil {
+		return nil, err
+	}
+	increase, err := conf.FieldInt(arlFieldIncrease)
+	if err != nil {
+		return nil, err
+	}
+	decreaseFactor, err := conf.FieldFloat(arlFieldDecreaseFactor)
+	if err != nil {
+		return nil, err
+	}
+	var latencyThreshold time.Duration
+	if test, _ := conf.FieldString(arlFieldLatencyThreshold); test != "" {
+		if latencyThreshold, err = conf.FieldDuration(arlFieldLatencyThreshold); err != nil {
+			return nil, err
+		}
+	}
+
+	r, err := newAdaptiveRatelimit(count, minCount, maxCount, interval)
+	if err != nil {
+		return nil, err
+	}
+	if increase < 0 {
+		return nil, errors.New("increase must not be negative")
+	}
+	if decreaseFactor <= 0 || decreaseFactor >= 1 {
+		return nil, errors.New("decrease_factor must be greater than zero and less than one")
+	}
+	r.increase = increase
+	r.decreaseFactor = decreaseFactor
+	r.latencyThreshold = latencyThreshold
+	return r, nil
+}
+
+//------------------------------------------------------------------------------
+
+type adaptiveRatelimit struct {
+	mut         sync.Mutex
+	bucket      int
+	lastRefresh time.Time
+
+	size   int
+	period time.Duration
+
+	minSize          int
+	maxSize          int
+	increase         int
+	decreaseFactor   float64
+	latencyThreshold time.Duration
+
+	lastDecrease time.Time
+	successes    int
+}
+
+func newAdaptiveRatelimit(count, minCount, maxCount int, interval time.Duration) (*adaptiveRatelimit, error) {
+	if minCount <= 0 {
+		return nil, errors.New("min_count must be larger than zero")
+	}
+	if maxCount < minCount {
+		return nil, errors.New("max_count must not be smaller than min_count")
+	}
+	if count < minCount || count > maxCount {
+		return nil, errors.New("count must be between min_count and max_count")
+	}
+	return &adaptiveRatelimit{
+		bucket:         count,
+		lastRefresh:    time.Now(),
+		size:           count,
+		period:         interval,
+		minSize:        minCount,
+		maxSize:        maxCount,
+		increase:       1,
+		decreaseFactor: 0.5,
+	}, nil
+}
+
+func (r *adaptiveRatelimit) Access(ctx context.Context) (time.Duration, error) {
+	r.mut.Lock()
+	r.bucket--
+
+	if r.bucket < 0 {
+		r.bucket = 0
+		remaining := r.period - time.Since(r.lastRefresh)
+
+		if remaining > 0 {
+			r.mut.Unlock()
+			return remaining, nil
+		}
+		r.refresh()
+		r.bucket = r.size - 1
+	}
+	r.mut.Unlock()
+	return 0, nil
+}
+
+// refresh begins a new interval, increasing the allowance if requests within
+// the previous interval succeeded without being throttled. Must be called
+// while holding the mutex.
+func (r *adaptiveRatelimit) refresh() {
+	if r.successes > 0 && time.Since(r.lastDecrease) >= r.period {
+		if r.size += r.increase; r.size > r.maxSize {
+			r.size = r.maxSize
+		}
+	}
+	r.successes = 0
+	r.lastRefresh = time.Now()
+}
+
+func (r *adaptiveRatelimit) Feedback(ctx context.Context, latency time.Duration, throttled bool) {
+	if r.latencyThreshold > 0 && latency > r.latencyThreshold {
+		throttled = true
+	}
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if !throttled {
+		r.successes++
+		return
+	}
+
+	// Only decrease once per interval so that a burst of throttled requests
+	// sent at the same allowance doesn't collapse it entirely.
+	if time.Since(r.lastDecrease) < r.period {
+		return
+	}
+	if r.size = int(float64(r.size) * r.decreaseFactor); r.size < r.minSize {
+		r.size = r.minSize
+	}
+	if r.bucket > r.size {
+		r.bucket = r.size
+	}
+	r.successes = 0
+	r.lastDecrease = time.Now()
+}
+
+func (r *adaptiveRatelimit) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,158 @@
+package pure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/internal/httpclient"
+	"github.com/redpanda-data/benthos/v4/internal/manager/mock"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func TestAdaptiveRateLimitConfErrors(t *testing.T) {
+	for _, confStr := range []string{
+		`min_count: 0`,
+		`count: 10
+max_count: 5`,
+		`count: 1
+min_count: 2`,
+		`decrease_factor: 1.5`,
+		`increase: -1`,
+		`latency_threshold: nope`,
+	} {
+		conf, err := adaptiveRatelimitConfig().ParseYAML(confStr, nil)
+		require.NoError(t, err, confStr)
+
+		_, err = newAdaptiveRatelimitFromConfig(conf)
+		require.Error(t, err, confStr)
+	}
+}
+
+func accessUntilLimited(t *testing.T, rl *adaptiveRatelimit) int {
+	t.Helper()
+
+	ctx := context.Background()
+	for i := 0; ; i++ {
+		period, err := rl.Access(ctx)
+		require.NoError(t, err)
+		if period > 0 {
+			return i
+		}
+	}
+}
+
+func TestAdaptiveRateLimitDecrease(t *testing.T) {
+	conf, err := adaptiveRatelimitConfig().ParseYAML(`
+count: 10
+min_count: 3
+interval: 10ms
+`, nil)
+	require.NoError(t, err)
+
+	rl, err := newAdaptiveRatelimitFromConfig(conf)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.Equal(t, 10, accessUntilLimited(t, rl))
+
+	// Multiple throttles within the same interval only decrease once.
+	rl.Feedback(ctx, time.Millisecond, true)
+	rl.Feedback(ctx, time.Millisecond, true)
+
+	<-time.After(time.Millisecond * 15)
+	assert.Equal(t, 5, accessUntilLimited(t, rl))
+
+	rl.Feedback(ctx, time.Millisecond, true)
+
+	<-time.After(time.Millisecond * 15)
+	assert.Equal(t, 3, accessUntilLimited(t, rl))
+}
+
+func TestAdaptiveRateLimitIncrease(t *testing.T) {
+	conf, err := adaptiveRatelimitConfig().ParseYAML(`
+count: 5
+max_count: 7
+interval: 10ms
+increase: 2
+latency_threshold: 100ms
+`, nil)
+	require.NoError(t, err)
+
+	rl, err := newAdaptiveRatelimitFromConfig(conf)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.Equal(t, 5, accessUntilLimited(t, rl))
+	rl.Feedback(ctx, time.Millisecond, false)
+
+	<-time.After(time.Millisecond * 15)
+	assert.Equal(t, 7, accessUntilLimited(t, rl))
+	rl.Feedback(ctx, time.Millisecond, false)
+
+	<-time.After(time.Millisecond * 15)
+	assert.Equal(t, 7, accessUntilLimited(t, rl))
+
+	// Slow requests are treated as throttled.
+	rl.Feedback(ctx, time.Second, false)
+
+	<-time.After(time.Millisecond * 15)
+	assert.Equal(t, 3, accessUntilLimited(t, rl))
+}
+
+func TestAdaptiveRateLimitHTTPClientFeedback(t *testing.T) {
+	var status atomic.Int64
+	status.Store(http.StatusTooManyRequests)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(ts.Close)
+
+	rl, err := newAdaptiveRatelimit(8, 1, 16, time.Millisecond*50)
+	require.NoError(t, err)
+
+	size := func() int {
+		rl.mut.Lock()
+		defer rl.mut.Unlock()
+		return rl.size
+	}
+
+	spec := service.NewConfigSpec().Field(httpclient.ConfigField("POST", false))
+	pConf, err := spec.ParseYAML(`
+url: `+ts.URL+`
+rate_limit: foo
+retries: 0
+`, nil)
+	require.NoError(t, err)
+
+	conf, err := httpclient.ConfigFromParsed(pConf)
+	require.NoError(t, err)
+
+	client, err := httpclient.NewClientFromOldConfig(conf, service.MockResources(func(m *mock.Manager) {
+		m.RateLimits["foo"] = rl
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Close(context.Background())
+	})
+
+	send := func() error {
+		_, err := client.Send(context.Background(), service.MessageBatch{service.NewMessage([]byte("hello"))})
+		return err
+	}
+
+	require.Error(t, send())
+	assert.Equal(t, 4, size())
+
+	status.Store(http.StatusOK)
+	assert.Eventually(t, func() bool {
+		return assert.NoError(t, send()) && size() > 4
+	}, time.Second*5, time.Millisecond*10)
+}
@@ -9,23 +9,33 @@ const (
 	fieldLevelName        = "level_name"
 	fieldMessageName      = "message_name"
 	fieldTimestampName    = "timestamp_name"
+	fieldTimestampFormat  = "timestamp_format"
 	fieldStaticFields     = "static_fields"
 	fieldFile             = "file"
 	fieldFilePath         = "path"
 	fieldFileRotate       = "rotate"
 	fieldFileRotateMaxAge = "rotate_max_age_days"
+	fieldFileRotateSize   = "rotate_max_size_mb"
+	fieldFileRotateFiles  = "rotate_max_backups"
+	fieldSampling         = "sampling"
+	fieldSamplingEnabled  = "enabled"
+	fieldSamplingInterval = "interval"
+	fieldSamplingInitial  = "initial"
+	fieldSamplingAfter    = "thereafter"
 )
 
 // Config holds configuration options for a logger object.
 type Config struct {
-	LogLevel      string            `yaml:"level"`
-	Format        string            `yaml:"format"`
-	AddTimeStamp  bool              `yaml:"add_timestamp"`
-	LevelName     string            `yaml:"level_name"`
-	MessageName   string            `yaml:"message_name"`
-	TimestampName string            `yaml:"timestamp_name"`
-	StaticFields  map[string]string `yaml:"static_fields"`
-	File          File              `yaml:"file"`
+	LogLevel        string            `yaml:"level"`
+	Format          string            `yaml:"format"`
+	AddTimeStamp    bool              `yaml:"add_timestamp"`
+	LevelName       string            `yaml:"level_name"`
+	MessageName     string            `yaml:"message_name"`
+	TimestampName   string            `yaml:"timestamp_name"`
+	TimestampFormat string            `yaml:"timestamp_format"`
+	StaticFields    map[string]string `yaml:"static_fields"`
+	File            File              `yaml:"file"`
+	Sampling        Sampling          `yaml:"sampling"`
 }
 
 // File contains configuration for file based logging.
@@ -33,20 +43,41 @@ type File struct {
 	Path         string `yaml:"path"`
 	Rotate       bool   `yaml:"rotate"`
 	RotateMaxAge int    `yaml:"rotate_max_age_days"`
+	RotateSize   int    `yaml:"rotate_max_size_mb"`
+	RotateFiles  int    `yaml:"rotate_max_backups"`
+}
+
+// Sampling contains configuration for limiting the rate of repeated logs.
+type Sampling struct {
+	Enabled    bool   `yaml:"enabled"`
+	Interval   string `yaml:"interval"`
+	Initial    int    `yaml:"initial"`
+	Thereafter int    `yaml:"thereafter"`
 }
 
 // NewConfig returns a config struct with the default values for each field.
 func NewConfig() Config {
 	return Config{
-		LogLevel:      "INFO",
-		Format:        "logfmt",
-		AddTimeStamp:  false,
-		LevelName:     "level",
-		TimestampName: "time",
-		MessageName:   "msg",
+		LogLevel:        "INFO",
+		Format:          "logfmt",
+		AddTimeStamp:    false,
+		LevelName:       "level",
+		TimestampName:   "time",
+		TimestampFormat: "rfc3339",
+		MessageName:     "msg",
 		StaticFields: map[string]string{
 			"@service": "benthos",
 		},
+		File: File{
+			RotateSize:  10,
+			RotateFiles: 1,
+		},
+		Sampling: Sampling{
+			Enabled:    false,
+			Interval:   "1s",
+			Initial:    10,
+			Thereafter: 0,
+		},
 	}
 }
 
@@ -91,6 +122,9 @@ func FromParsed(pConf *docs.ParsedConfig) (conf Config, err error) {
 	if conf.TimestampName, err = pConf.FieldString(fieldTimestampName); err != nil {
 		return
 	}
+	if conf.TimestampFormat, err = pConf.FieldString(fieldTimestampFormat); err != nil {
+		return
+	}
 	if conf.StaticFields, err = pConf.FieldStringMap(fieldStaticFields); err != nil {
 		return
 	}
@@ -106,6 +140,28 @@ func FromParsed(pConf *docs.ParsedConfig) (conf Config, err error) {
 		if conf.File.RotateMaxAge, err = fConf.FieldInt(fieldFileRotateMaxAge); err != nil {
 			return
 		}
+		if conf.File.RotateSize, err = fConf.FieldInt(fieldFileRotateSize); err != nil {
+			return
+		}
+		if conf.File.RotateFiles, err = fConf.FieldInt(fieldFileRotateFiles); err != nil {
+			return
+		}
+	}
+
+	if pConf.Contains(fieldSampling) {
+		sConf := pConf.Namespace(fieldSampling)
+		if conf.Sampling.Enabled, err = sConf.FieldBool(fieldSamplingEnabled); err != nil {
+			return
+		}
+		if conf.Sampling.Interval, err = sConf.FieldString(fieldSamplingInterval); err != nil {
+			return
+		}
+		if conf.Sampling.Initial, err = sConf.FieldInt(fieldSamplingInitial); err != nil {
+			return
+		}
+		if conf.Sampling.Thereafter, err = sConf.FieldInt(fieldSamplingAfter); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -14,6 +14,12 @@ func Spec() docs.FieldSpecs {
 		docs.FieldBool(fieldAddTimeStamp, "Whether to include timestamps in logs.").HasDefault(false),
 		docs.FieldString(fieldLevelName, "The name of the level field added to logs when the `format` is `json`.").HasDefault("level").Advanced(),
 		docs.FieldString(fieldTimestampName, "The name of the timestamp field added to logs when `add_timestamp` is set to `true` and the `format` is `json`.").HasDefault("time").Advanced(),
+		docs.FieldString(fieldTimestampFormat, "The format of timestamps added to logs when `add_timestamp` is set to `true`.").HasAnnotatedOptions(
+			"rfc3339", "An RFC3339 formatted string with second precision.",
+			"rfc3339_nano", "An RFC3339 formatted string with nanosecond precision.",
+			"unix", "An integer of seconds since the unix epoch.",
+			"unix_ms", "An integer of milliseconds since the unix epoch.",
+		).HasDefault("rfc3339").Advanced().AtVersion("4.39.0"),
 		docs.FieldString(fieldMessageName, "The name of the message field added to logs when the `format` is `json`.").HasDefault("msg").Advanced(),
 		docs.FieldString(fieldStaticFields, "A map of key/value pairs to add to each structured log.").Map().HasDefault(map[string]any{
 			"@service": "benthos",
@@ -22,6 +28,14 @@ func Spec() docs.FieldSpecs {
 			docs.FieldString(fieldFilePath, "The file path to write logs to, if the file does not exist it will be created. Leave this field empty or unset to disable file based logging.").HasDefault(""),
 			docs.FieldBool(fieldFileRotate, "Whether to rotate log files automatically.").HasDefault(false),
 			docs.FieldInt(fieldFileRotateMaxAge, "The maximum number of days to retain old log files based on the timestamp encoded in their filename, after which they are deleted. Setting to zero disables this mechanism.").HasDefault(0),
+			docs.FieldInt(fieldFileRotateSize, "The maximum size in megabytes of a log file before it is rotated. Setting to zero uses a default of 100 megabytes.").HasDefault(10).AtVersion("4.39.0"),
+			docs.FieldInt(fieldFileRotateFiles, "The maximum number of old log files to retain, after which the oldest are deleted. Setting to zero retains all old log files, subject to `rotate_max_age_days`.").HasDefault(1).AtVersion("4.39.0"),
 		).Advanced(),
+		docs.FieldObject(fieldSampling, "Limit the rate at which identical log lines are emitted, where two logs are identical when both their level and message match. This is useful for preventing repetitive errors, such as a failing connection, from flooding logs.").WithChildren(
+			docs.FieldBool(fieldSamplingEnabled, "Whether to limit the rate of identical log lines.").HasDefault(false),
+			docs.FieldString(fieldSamplingInterval, "The period of time over which identical log lines are counted.").HasDefault("1s"),
+			docs.FieldInt(fieldSamplingInitial, "The number of identical log lines to emit within each interval before sampling begins.").HasDefault(10),
+			docs.FieldInt(fieldSamplingAfter, "After the initial log lines of an interval, emit only every Nth identical log line. Setting to zero drops all further identical log lines within the interval.").HasDefault(0),
+		).Advanced().AtVersion("4.39.0"),
 	}
 }
@@ -0,0 +1,141 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LevelSetter is implemented by loggers that support changing the minimum
+// severity level of emitted logs at runtime.
+type LevelSetter interface {
+	// SetLevel sets the minimum severity level of logs. When a non-empty path
+	// prefix is provided the level only applies to logs of components with a
+	// path beginning with that prefix, and an empty level removes the
+	// override for that prefix.
+	SetLevel(level, pathPrefix string) error
+
+	// Levels returns the current minimum severity level along with any path
+	// prefix overrides.
+	Levels() (level string, overrides map[string]string)
+}
+
+func parseLevel(level string) (logrus.Level, error) {
+	switch strings.ToUpper(level) {
+	case "OFF", "NONE":
+		return logrus.PanicLevel, nil
+	case "FATAL":
+		return logrus.FatalLevel, nil
+	case "ERROR":
+		return logrus.ErrorLevel, nil
+	case "WARN":
+		return logrus.WarnLevel, nil
+	case "INFO":
+		return logrus.InfoLevel, nil
+	case "DEBUG":
+		return logrus.DebugLevel, nil
+	case "TRACE", "ALL":
+		return logrus.TraceLevel, nil
+	}
+	return logrus.InfoLevel, fmt.Errorf("log level '%v' not recognized", level)
+}
+
+func levelName(level logrus.Level) string {
+	switch level {
+	case logrus.PanicLevel:
+		return "OFF"
+	case logrus.FatalLevel:
+		return "FATAL"
+	case logrus.ErrorLevel:
+		return "ERROR"
+	case logrus.WarnLevel:
+		return "WARN"
+	case logrus.InfoLevel:
+		return "INFO"
+	case logrus.DebugLevel:
+		return "DEBUG"
+	}
+	return "TRACE"
+}
+
+// levelState holds the minimum severity levels of a logger and all loggers
+// derived from it.
+type levelState struct {
+	mut       sync.RWMutex
+	level     logrus.Level
+	overrides map[string]logrus.Level
+	prefixes  []string
+}
+
+func newLevelState(level logrus.Level) *levelState {
+	return &levelState{
+		level:     level,
+		overrides: map[string]logrus.Level{},
+	}
+}
+
+// enabled returns whether logs of a level should be emitted by a component of
+// a given path, where the longest override matching whole segments of the path
+// wins.
+func (s *levelState) enabled(level logrus.Level, path string) bool {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	threshold := s.level
+	if path != "" {
+		for _, p := range s.prefixes {
+			if path == p || strings.HasPrefix(path, p+".") {
+				threshold = s.overrides[p]
+				break
+			}
+		}
+	}
+	return level <= threshold
+}
+
+func (s *levelState) set(level, pathPrefix string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if pathPrefix != "" && level == "" {
+		delete(s.overrides, pathPrefix)
+	} else {
+		l, err := parseLevel(level)
+		if err != nil {
+			return err
+		}
+		if pathPrefix == "" {
+			s.level = l
+			return nil
+		}
+		s.overrides[pathPrefix] = l
+	}
+
+	// Prefixes are checked longest first so that the most specific override
+	// is applied.
+	s.prefixes = s.prefixes[:0]
+	for p := range s.overrides {
+		s.prefixes = append(s.prefixes, p)
+	}
+	sort.Slice(s.prefixes, func(i, j int) bool {
+		if len(s.prefixes[i]) == len(s.prefixes[j]) {
+			return s.prefixes[i] < s.prefixes[j]
+		}
+		return len(s.prefixes[i]) > len(s.prefixes[j])
+	})
+	return nil
+}
+
+func (s *levelState) get() (level string, overrides map[string]string) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	overrides = make(map[string]string, len(s.overrides))
+	for k, v := range s.overrides {
+		overrides[k] = levelName(v)
+	}
+	return levelName(s.level), overrides
+}
@@ -6,6 +6,8 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -15,7 +17,10 @@ import (
 
 // Logger is an object with support for levelled logging and modular components.
 type Logger struct {
-	entry *logrus.Entry
+	entry   *logrus.Entry
+	sampler *sampler
+	levels  *levelState
+	path    string
 }
 
 // New returns a new logger from a config, or returns an error if the config
@@ -25,9 +30,9 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 		if config.File.Rotate {
 			stream = &lumberjack.Logger{
 				Filename:   config.File.Path,
-				MaxSize:    10,
+				MaxSize:    config.File.RotateSize,
 				MaxAge:     config.File.RotateMaxAge,
-				MaxBackups: 1,
+				MaxBackups: config.File.RotateFiles,
 				Compress:   true,
 			}
 		} else {
@@ -47,23 +52,46 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 	logger := logrus.New()
 	logger.Out = stream
 
+	addTimestamp, timeKey := config.AddTimeStamp, config.TimestampName
+	var timestampFormat string
+	switch config.TimestampFormat {
+	case "rfc3339", "":
+		timestampFormat = time.RFC3339
+	case "rfc3339_nano":
+		timestampFormat = time.RFC3339Nano
+	case "unix", "unix_ms":
+		// Epoch timestamps are added as a regular field by a hook, and
+		// therefore the timestamp of the formatter is disabled.
+		if addTimestamp {
+			logger.AddHook(epochTimestampHook{
+				key:    config.TimestampName,
+				millis: config.TimestampFormat == "unix_ms",
+			})
+		}
+		addTimestamp, timeKey = false, ""
+	default:
+		return nil, fmt.Errorf("log timestamp format '%v' not recognized", config.TimestampFormat)
+	}
+
 	switch config.Format {
 	case "json":
 		logger.SetFormatter(&logrus.JSONFormatter{
-			DisableTimestamp: !config.AddTimeStamp,
+			DisableTimestamp: !addTimestamp,
+			TimestampFormat:  timestampFormat,
 			FieldMap: logrus.FieldMap{
-				logrus.FieldKeyTime:  config.TimestampName,
+				logrus.FieldKeyTime:  timeKey,
 				logrus.FieldKeyMsg:   config.MessageName,
 				logrus.FieldKeyLevel: config.LevelName,
 			},
 		})
 	case "logfmt":
 		logger.SetFormatter(&logrus.TextFormatter{
-			DisableTimestamp: !config.AddTimeStamp,
+			DisableTimestamp: !addTimestamp,
 			QuoteEmptyFields: true,
-			FullTimestamp:    config.AddTimeStamp,
+			FullTimestamp:    addTimestamp,
+			TimestampFormat:  timestampFormat,
 			FieldMap: logrus.FieldMap{
-				logrus.FieldKeyTime:  config.TimestampName,
+				logrus.FieldKeyTime:  timeKey,
 				logrus.FieldKeyMsg:   config.MessageName,
 				logrus.FieldKeyLevel: config.LevelName,
 			},
@@ -72,23 +100,14 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 		return nil, fmt.Errorf("log format '%v' not recognized", config.Format)
 	}
 
-	switch strings.ToUpper(config.LogLevel) {
-	case "OFF", "NONE":
-		logger.Level = logrus.PanicLevel
-	case "FATAL":
-		logger.Level = logrus.FatalLevel
-	case "ERROR":
-		logger.Level = logrus.ErrorLevel
-	case "WARN":
-		logger.Level = logrus.WarnLevel
-	case "INFO":
-		logger.Level = logrus.InfoLevel
-	case "DEBUG":
-		logger.Level = logrus.DebugLevel
-	case "TRACE", "ALL":
-		logger.Level = logrus.TraceLevel
-		logger.Level = logrus.TraceLevel
-	}
+	// Levels are filtered by the level state rather than logrus in order to
+	// support per component overrides, and therefore logrus itself emits all
+	// levels.
+	logger.Level = logrus.TraceLevel
+
+	// Unrecognised levels are caught by config linting and otherwise default
+	// to INFO.
+	level, _ := parseLevel(config.LogLevel)
 
 	sFields := logrus.Fields{}
 	for k, v := range config.StaticFields {
@@ -96,7 +115,82 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 	}
 	logEntry := logger.WithFields(sFields)
 
-	return &Logger{entry: logEntry}, nil
+	var s *sampler
+	if config.Sampling.Enabled {
+		var err error
+		if s, err = newSampler(config.Sampling); err != nil {
+			return nil, err
+		}
+	}
+	return &Logger{entry: logEntry, sampler: s, levels: newLevelState(level)}, nil
+}
+
+//------------------------------------------------------------------------------
+
+type epochTimestampHook struct {
+	key    string
+	millis bool
+}
+
+func (h epochTimestampHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h epochTimestampHook) Fire(e *logrus.Entry) error {
+	if h.millis {
+		e.Data[h.key] = e.Time.UnixMilli()
+	} else {
+		e.Data[h.key] = e.Time.Unix()
+	}
+	return nil
+}
+
+// sampler limits the rate at which identical log lines are emitted, it is
+// shared by all loggers derived from the same root.
+type sampler struct {
+	interval   time.Duration
+	initial    int
+	thereafter int
+
+	mut         sync.Mutex
+	windowStart time.Time
+	counts      map[string]int
+}
+
+func newSampler(conf Sampling) (*sampler, error) {
+	interval, err := time.ParseDuration(conf.Interval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse log sampling interval: %w", err)
+	}
+	if conf.Initial < 0 || conf.Thereafter < 0 {
+		return nil, errors.New("log sampling initial and thereafter values must not be negative")
+	}
+	return &sampler{
+		interval:   interval,
+		initial:    conf.Initial,
+		thereafter: conf.Thereafter,
+		counts:     map[string]int{},
+	}, nil
+}
+
+// allow returns whether a log of a given level and message should be emitted.
+func (s *sampler) allow(level logrus.Level, msg string) bool {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if now := time.Now(); now.Sub(s.windowStart) >= s.interval {
+		s.windowStart = now
+		s.counts = map[string]int{}
+	}
+
+	key := level.String() + ":" + msg
+	s.counts[key]++
+
+	n := s.counts[key]
+	if n <= s.initial {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.initial)%s.thereafter == 0
 }
 
 //------------------------------------------------------------------------------
@@ -105,7 +199,10 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 func Noop() Modular {
 	logger := logrus.New()
 	logger.Out = io.Discard
-	return &Logger{entry: logger.WithFields(logrus.Fields{})}
+	return &Logger{
+		entry:  logger.WithFields(logrus.Fields{}),
+		levels: newLevelState(logrus.InfoLevel),
+	}
 }
 
 // WithFields returns a logger with new fields added to the JSON formatted
@@ -118,6 +215,9 @@ func (l *Logger) WithFields(inboundFields map[string]string) Modular {
 
 	newLogger := *l
 	newLogger.entry = l.entry.WithFields(newFields)
+	if p, exists := inboundFields["path"]; exists {
+		newLogger.path = p
+	}
 	return &newLogger
 }
 
@@ -135,67 +235,69 @@ func (l *Logger) With(keyValues ...any) Modular {
 
 	newLogger := *l
 	newLogger.entry = newEntry
+	if p, exists := newEntry.Data["path"].(string); exists {
+		newLogger.path = p
+	}
 	return &newLogger
 }
 
+// SetLevel sets the minimum severity level of logs emitted by this logger and
+// all loggers sharing the same root. When a path prefix is provided the level
+// only applies to components with a path beginning with the prefix, and an
+// empty level removes the override of that prefix.
+func (l *Logger) SetLevel(level, pathPrefix string) error {
+	return l.levels.set(level, pathPrefix)
+}
+
+// Levels returns the current minimum severity level of logs along with any
+// path prefix overrides.
+func (l *Logger) Levels() (level string, overrides map[string]string) {
+	return l.levels.get()
+}
+
 //------------------------------------------------------------------------------
 
-// Fatal prints a fatal message to the console. Does NOT cause panic.
-func (l *Logger) Fatal(format string, v ...any) {
-	f := strings.TrimSuffix(format, "\n")
+func (l *Logger) log(level logrus.Level, format string, v ...any) {
+	if !l.levels.enabled(level, l.path) {
+		return
+	}
+	msg := strings.TrimSuffix(format, "\n")
 	if len(v) > 0 {
-		l.entry.Fatalf(f, v...)
-	} else {
-		l.entry.Fatal(f)
+		msg = fmt.Sprintf(msg, v...)
+	}
+	if l.sampler != nil && !l.sampler.allow(level, msg) {
+		return
 	}
+	l.entry.Log(level, msg)
+}
+
+// Fatal prints a fatal message to the console. Does NOT cause panic.
+func (l *Logger) Fatal(format string, v ...any) {
+	l.log(logrus.FatalLevel, format, v...)
+	l.entry.Logger.Exit(1)
 }
 
 // Error prints an error message to the console.
 func (l *Logger) Error(format string, v ...any) {
-	f := strings.TrimSuffix(format, "\n")
-	if len(v) > 0 {
-		l.entry.Errorf(f, v...)
-	} else {
-		l.entry.Error(f)
-	}
+	l.log(logrus.ErrorLevel, format, v...)
 }
 
 // Warn prints a warning message to the console.
 func (l *Logger) Warn(format string, v ...any) {
-	f := strings.TrimSuffix(format, "\n")
-	if len(v) > 0 {
-		l.entry.Warnf(f, v...)
-	} else {
-		l.entry.Warn(f)
-	}
+	l.log(logrus.WarnLevel, format, v...)
 }
 
 // Info prints an information message to the console.
 func (l *Logger) Info(format string, v ...any) {
-	f := strings.TrimSuffix(format, "\n")
-	if len(v) > 0 {
-		l.entry.Infof(f, v...)
-	} else {
-		l.entry.Info(f)
-	}
+	l.log(logrus.InfoLevel, format, v...)
 }
 
 // Debug prints a debug message to the console.
 func (l *Logger) Debug(format string, v ...any) {
-	f := strings.TrimSuffix(format, "\n")
-	if len(v) > 0 {
-		l.entry.Debugf(f, v...)
-	} else {
-		l.entry.Debug(f)
-	}
+	l.log(logrus.DebugLevel, format, v...)
 }
 
 // Trace prints a trace message to the console.
 func (l *Logger) Trace(format string, v ...any) {
-	f := strings.TrimSuffix(format, "\n")
-	if len(v) > 0 {
-		l.entry.Tracef(f, v...)
-	} else {
-		l.entry.Trace(f)
-	}
+	l.log(logrus.TraceLevel, format, v...)
 }
@@ -2,7 +2,11 @@ package log
 
 import (
 	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -165,3 +169,148 @@ func TestLogLevels(t *testing.T) {
 		}
 	}
 }
+
+func TestLoggerTimestampFormats(t *testing.T) {
+	tests := []struct {
+		format   string
+		logFmt   string
+		expected string
+	}{
+		{format: "rfc3339", logFmt: "json", expected: `^\{"@service":"benthos","level":"info","msg":"hello","time":"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}[^".]+"\}\n$`},
+		{format: "rfc3339_nano", logFmt: "json", expected: `^\{"@service":"benthos","level":"info","msg":"hello","time":"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d+[^"]+"\}\n$`},
+		{format: "unix", logFmt: "json", expected: `^\{"@service":"benthos","level":"info","msg":"hello","time":\d{10}\}\n$`},
+		{format: "unix_ms", logFmt: "json", expected: `^\{"@service":"benthos","level":"info","msg":"hello","time":\d{13}\}\n$`},
+		{format: "unix", logFmt: "logfmt", expected: `^level=info msg=hello @service=benthos time=\d{10}\n$`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format+"_"+test.logFmt, func(t *testing.T) {
+			loggerConfig := NewConfig()
+			loggerConfig.Format = test.logFmt
+			loggerConfig.AddTimeStamp = true
+			loggerConfig.TimestampFormat = test.format
+
+			var buf bytes.Buffer
+
+			logger, err := New(&buf, ifs.OS(), loggerConfig)
+			require.NoError(t, err)
+
+			logger.Info("hello")
+			assert.Regexp(t, test.expected, buf.String())
+		})
+	}
+
+	loggerConfig := NewConfig()
+	loggerConfig.TimestampFormat = "nope"
+
+	_, err := New(&bytes.Buffer{}, ifs.OS(), loggerConfig)
+	require.Error(t, err)
+}
+
+func TestLoggerSampling(t *testing.T) {
+	loggerConfig := NewConfig()
+	loggerConfig.Sampling = Sampling{
+		Enabled:    true,
+		Interval:   "1h",
+		Initial:    2,
+		Thereafter: 3,
+	}
+
+	buf := logCounter{}
+
+	logger, err := New(&buf, ifs.OS(), loggerConfig)
+	require.NoError(t, err)
+
+	for i := 0; i < 8; i++ {
+		logger.Error("connection failed: %v", "nope")
+	}
+	// The first two logs are emitted, followed by every third.
+	assert.Equal(t, 4, buf.count)
+
+	logger.Warn("connection failed: nope")
+	logger.With("foo", "bar").Error("something else")
+	assert.Equal(t, 6, buf.count)
+
+	// Disabled levels are not counted.
+	for i := 0; i < 5; i++ {
+		logger.Debug("debug log")
+	}
+	assert.Equal(t, 6, buf.count)
+}
+
+func TestLoggerFileRotation(t *testing.T) {
+	dir := t.TempDir()
+
+	loggerConfig := NewConfig()
+	loggerConfig.File = File{
+		Path:        filepath.Join(dir, "benthos.log"),
+		Rotate:      true,
+		RotateSize:  1,
+		RotateFiles: 1,
+	}
+
+	logger, err := New(io.Discard, ifs.OS(), loggerConfig)
+	require.NoError(t, err)
+
+	line := strings.Repeat("a", 1024)
+	for i := 0; i < 1200; i++ {
+		logger.Info(line)
+	}
+
+	assert.FileExists(t, loggerConfig.File.Path)
+	assert.Eventually(t, func() bool {
+		backups, _ := filepath.Glob(filepath.Join(dir, "benthos-*.log.gz"))
+		return len(backups) == 1
+	}, time.Second*5, time.Millisecond*50)
+}
+
+func TestLoggerSetLevel(t *testing.T) {
+	loggerConfig := NewConfig()
+	loggerConfig.LogLevel = "WARN"
+
+	buf := logCounter{}
+
+	logger, err := New(&buf, ifs.OS(), loggerConfig)
+	require.NoError(t, err)
+
+	inputLogger := logger.WithFields(map[string]string{"path": "root.input"})
+	procLogger := logger.With("path", "root.pipeline.processors.0")
+
+	logAll := func() {
+		for _, l := range []Modular{logger, inputLogger, procLogger} {
+			l.Info("info test")
+			l.Debug("debug test")
+		}
+	}
+
+	logAll()
+	assert.Equal(t, 0, buf.count)
+
+	require.NoError(t, logger.(LevelSetter).SetLevel("DEBUG", "root.pipeline"))
+	logAll()
+	assert.Equal(t, 2, buf.count)
+
+	require.NoError(t, logger.(LevelSetter).SetLevel("INFO", ""))
+	logAll()
+	assert.Equal(t, 6, buf.count)
+
+	level, overrides := logger.(LevelSetter).Levels()
+	assert.Equal(t, "INFO", level)
+	assert.Equal(t, map[string]string{"root.pipeline": "DEBUG"}, overrides)
+
+	require.NoError(t, inputLogger.(LevelSetter).SetLevel("", "root.pipeline"))
+	logAll()
+	assert.Equal(t, 9, buf.count)
+
+	require.Error(t, logger.(LevelSetter).SetLevel("nope", ""))
+
+	// Overrides only apply to whole path segments.
+	siblingLogger := logger.With("path", "root.inputs_foo")
+	require.NoError(t, logger.(LevelSetter).SetLevel("DEBUG", "root.input"))
+
+	siblingLogger.Debug("debug test")
+	assert.Equal(t, 9, buf.count)
+
+	inputLogger.Debug("debug test")
+	assert.Equal(t, 10, buf.count)
+}
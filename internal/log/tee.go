@@ -61,3 +61,25 @@ func (t *teeLogger) Trace(format string, v ...any) {
 	t.a.Trace(format, v...)
 	t.b.Trace(format, v...)
 }
+
+// SetLevel sets the level of both loggers where supported.
+func (t *teeLogger) SetLevel(level, pathPrefix string) error {
+	for _, l := range []Modular{t.a, t.b} {
+		if ls, ok := l.(LevelSetter); ok {
+			if err := ls.SetLevel(level, pathPrefix); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Levels returns the levels of the first logger that supports them.
+func (t *teeLogger) Levels() (level string, overrides map[string]string) {
+	for _, l := range []Modular{t.a, t.b} {
+		if ls, ok := l.(LevelSetter); ok {
+			return ls.Levels()
+		}
+	}
+	return "", nil
+}
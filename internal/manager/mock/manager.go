@@ -31,7 +31,7 @@ type Manager struct {
 
 	Inputs     map[string]*Input
 	Caches     map[string]map[string]CacheItem
-	RateLimits map[string]RateLimit
+	RateLimits map[string]ratelimit.V1
 	Outputs    map[string]OutputWriter
 	Processors map[string]Processor
 	Pipes      map[string]<-chan message.Transaction
@@ -55,7 +55,7 @@ func NewManager() *Manager {
 		Version:       "mock",
 		Inputs:        map[string]*Input{},
 		Caches:        map[string]map[string]CacheItem{},
-		RateLimits:    map[string]RateLimit{},
+		RateLimits:    map[string]ratelimit.V1{},
 		Outputs:       map[string]OutputWriter{},
 		Processors:    map[string]Processor{},
 		Pipes:         map[string]<-chan message.Transaction{},
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -45,6 +46,18 @@ func (m *Type) registerEndpoints(enableCrud bool) {
 		"GET a structured JSON object containing metrics for the stream.",
 		m.HandleStreamStats,
 	)
+	m.manager.RegisterEndpoint(
+		"/streams/{id}/versions",
+		"GET a list of the previous versions of a stream configuration that can be rolled back to.",
+		m.HandleStreamVersions,
+	)
+	m.manager.RegisterEndpoint(
+		"/streams/{id}/rollback",
+		"POST: Replace a stream with a previous version of its configuration,"+
+			" which is the most recent previous version unless a `version`"+
+			" query parameter is specified.",
+		m.HandleStreamRollback,
+	)
 	m.manager.RegisterEndpoint(
 		"/streams/{id}",
 		"Perform CRUD operations on streams, supporting POST (Create),"+
@@ -382,11 +395,13 @@ func (m *Type) HandleStreamCRUD(w http.ResponseWriter, r *http.Request) {
 			var bodyBytes []byte
 			if bodyBytes, serverErr = json.Marshal(struct {
 				Active    bool    `json:"active"`
+				Version   int     `json:"version"`
 				Uptime    float64 `json:"uptime"`
 				UptimeStr string  `json:"uptime_str"`
 				Config    any     `json:"config"`
 			}{
 				Active:    info.IsRunning(),
+				Version:   info.Version(),
 				Uptime:    info.Uptime().Seconds(),
 				UptimeStr: info.Uptime().String(),
 				Config:    sanit,
@@ -631,6 +646,118 @@ func (m *Type) HandleStreamStats(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// HandleStreamVersions is an http.HandleFunc for obtaining the previous
+// versions of a stream configuration.
+func (m *Type) HandleStreamVersions(w http.ResponseWriter, r *http.Request) {
+	var serverErr, requestErr error
+	defer func() {
+		if r.Body != nil {
+			r.Body.Close()
+		}
+		if serverErr != nil {
+			m.manager.Logger().Error("Stream versions Error: %v\n", serverErr)
+			http.Error(w, fmt.Sprintf("Error: %v", serverErr), http.StatusBadGateway)
+			return
+		}
+		if requestErr != nil {
+			m.manager.Logger().Debug("Stream request versions Error: %v\n", requestErr)
+			http.Error(w, fmt.Sprintf("Error: %v", requestErr), http.StatusBadRequest)
+			return
+		}
+	}()
+
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		http.Error(w, "Var `id` must be set", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		var hist []StreamVersion
+		if hist, serverErr = m.History(id); serverErr == nil {
+			type versionInfo struct {
+				Version int `json:"version"`
+				Config  any `json:"config"`
+			}
+			versions := make([]versionInfo, 0, len(hist))
+			for _, v := range hist {
+				versions = append(versions, versionInfo{
+					Version: v.Version,
+					Config:  v.Config.GetRawSource(),
+				})
+			}
+
+			jBytes, err := json.Marshal(versions)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(jBytes)
+		}
+	default:
+		requestErr = fmt.Errorf("verb not supported: %v", r.Method)
+	}
+	if serverErr == ErrStreamDoesNotExist {
+		serverErr = nil
+		http.Error(w, "Stream not found", http.StatusNotFound)
+		return
+	}
+}
+
+// HandleStreamRollback is an http.HandleFunc for replacing a stream with a
+// previous version of its configuration.
+func (m *Type) HandleStreamRollback(w http.ResponseWriter, r *http.Request) {
+	var serverErr, requestErr error
+	defer func() {
+		if r.Body != nil {
+			r.Body.Close()
+		}
+		if serverErr != nil {
+			m.manager.Logger().Error("Stream rollback Error: %v\n", serverErr)
+			http.Error(w, fmt.Sprintf("Error: %v", serverErr), http.StatusBadGateway)
+			return
+		}
+		if requestErr != nil {
+			m.manager.Logger().Debug("Stream request rollback Error: %v\n", requestErr)
+			http.Error(w, fmt.Sprintf("Error: %v", requestErr), http.StatusBadRequest)
+			return
+		}
+	}()
+
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		http.Error(w, "Var `id` must be set", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method != "POST" {
+		requestErr = fmt.Errorf("verb not supported: %v", r.Method)
+		return
+	}
+
+	var version int
+	if vStr := r.URL.Query().Get("version"); vStr != "" {
+		if version, requestErr = strconv.Atoi(vStr); requestErr != nil {
+			return
+		}
+	}
+
+	serverErr = m.Rollback(r.Context(), id, version)
+	if serverErr == ErrStreamDoesNotExist {
+		serverErr = nil
+		http.Error(w, "Stream not found", http.StatusNotFound)
+		return
+	}
+	if serverErr == ErrStreamVersionDoesNotExist {
+		serverErr = nil
+		http.Error(w, "Stream version not found", http.StatusNotFound)
+		return
+	}
+}
+
 // HandleStreamReady is an http.HandleFunc for providing a ready check across
 // all streams.
 func (m *Type) HandleStreamReady(w http.ResponseWriter, r *http.Request) {
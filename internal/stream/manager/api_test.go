@@ -39,6 +39,8 @@ func router(m *manager.Type) *mux.Router {
 	router.HandleFunc("/streams", m.HandleStreamsCRUD)
 	router.HandleFunc("/streams/{id}", m.HandleStreamCRUD)
 	router.HandleFunc("/streams/{id}/stats", m.HandleStreamStats)
+	router.HandleFunc("/streams/{id}/versions", m.HandleStreamVersions)
+	router.HandleFunc("/streams/{id}/rollback", m.HandleStreamRollback)
 	router.HandleFunc("/resources/{type}/{id}", m.HandleResourceCRUD)
 	return router
 }
@@ -107,6 +109,7 @@ func parseListBody(data *bytes.Buffer) listBody {
 
 type getBody struct {
 	Active    bool    `json:"active"`
+	Version   int     `json:"version"`
 	Uptime    float64 `json:"uptime"`
 	UptimeStr string  `json:"uptime_str"`
 	Config    any     `json:"config"`
@@ -345,6 +348,118 @@ func TestTypeAPIPatch(t *testing.T) {
 	assert.Equal(t, "2s", gabs.Wrap(info.Config).S("input", "generate", "interval").Data())
 }
 
+func TestTypeAPIVersionsRollback(t *testing.T) {
+	res, err := bmanager.New(bmanager.NewResourceConfig())
+	require.NoError(t, err)
+
+	mgr := manager.New(res)
+
+	r := router(mgr)
+
+	request := genRequest("POST", "/streams/foo/rollback", nil)
+	response := httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusNotFound, response.Code, response.Body.String())
+
+	request = genRequest("POST", "/streams/foo", harmlessConf())
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	request = genRequest("POST", "/streams/foo/rollback", nil)
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusNotFound, response.Code, response.Body.String())
+
+	for _, interval := range []string{"2s", "3s"} {
+		request = genRequest("PATCH", "/streams/foo", map[string]any{
+			"input": map[string]any{
+				"generate": map[string]any{
+					"interval": interval,
+				},
+			},
+		})
+		response = httptest.NewRecorder()
+		r.ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	}
+
+	getInfo := func() getBody {
+		t.Helper()
+		request := genRequest("GET", "/streams/foo", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+		return parseGetBody(t, response.Body)
+	}
+
+	info := getInfo()
+	assert.Equal(t, 3, info.Version)
+	assert.Equal(t, "3s", gabs.Wrap(info.Config).S("input", "generate", "interval").Data())
+
+	request = genRequest("GET", "/streams/foo/versions", nil)
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	var versions []struct {
+		Version int `json:"version"`
+		Config  any `json:"config"`
+	}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &versions))
+	require.Len(t, versions, 2)
+	assert.Equal(t, 1, versions[0].Version)
+	assert.Equal(t, 2, versions[1].Version)
+	assert.Equal(t, "2s", gabs.Wrap(versions[1].Config).S("input", "generate", "interval").Data())
+
+	request = genRequest("POST", "/streams/foo/rollback", nil)
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	info = getInfo()
+	assert.Equal(t, 4, info.Version)
+	assert.Equal(t, "2s", gabs.Wrap(info.Config).S("input", "generate", "interval").Data())
+
+	request = genRequest("POST", "/streams/foo/rollback?version=5", nil)
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusNotFound, response.Code, response.Body.String())
+
+	request = genRequest("POST", "/streams/foo/rollback?version=1", nil)
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	info = getInfo()
+	assert.Equal(t, 5, info.Version)
+	assert.Nil(t, gabs.Wrap(info.Config).S("input", "generate", "interval").Data())
+
+	request = genRequest("GET", "/streams/foo/versions", nil)
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	versions = nil
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &versions))
+	require.Len(t, versions, 4)
+	for i, v := range versions {
+		assert.Equal(t, i+1, v.Version)
+	}
+	assert.Equal(t, "3s", gabs.Wrap(versions[2].Config).S("input", "generate", "interval").Data())
+	assert.Equal(t, "2s", gabs.Wrap(versions[3].Config).S("input", "generate", "interval").Data())
+
+	request = genRequest("DELETE", "/streams/foo", nil)
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	request = genRequest("GET", "/streams/foo/versions", nil)
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusNotFound, response.Code, response.Body.String())
+}
+
 func TestTypeAPIBasicOperationsYAML(t *testing.T) {
 	res, err := bmanager.New(bmanager.NewResourceConfig())
 	require.NoError(t, err)
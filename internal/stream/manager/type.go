@@ -21,13 +21,15 @@ type StreamStatus struct {
 	config       stream.Config
 	strm         *stream.Type
 	metrics      *metrics.Local
+	version      int
 	createdAt    time.Time
 }
 
-func newStreamStatus(conf stream.Config, stats *metrics.Local) *StreamStatus {
+func newStreamStatus(conf stream.Config, version int, stats *metrics.Local) *StreamStatus {
 	return &StreamStatus{
 		config:    conf,
 		metrics:   stats,
+		version:   version,
 		createdAt: time.Now(),
 	}
 }
@@ -61,6 +63,12 @@ func (s *StreamStatus) Config() stream.Config {
 	return s.config
 }
 
+// Version returns the version of the stream configuration, which starts at 1
+// when the stream is created and is incremented with each update or rollback.
+func (s *StreamStatus) Version() int {
+	return s.version
+}
+
 // Metrics returns a metrics aggregator of the stream.
 func (s *StreamStatus) Metrics() *metrics.Local {
 	return s.metrics
@@ -79,14 +87,29 @@ type StreamProcConstructorFunc func(streamID string) (processor.V1, error)
 
 //------------------------------------------------------------------------------
 
+// StreamVersion is a previous configuration of a stream that can be rolled
+// back to.
+type StreamVersion struct {
+	Version int
+	Config  stream.Config
+}
+
+//------------------------------------------------------------------------------
+
 // Type manages a collection of streams, providing APIs for CRUD operations on
 // the streams.
 type Type struct {
 	closed  bool
 	streams map[string]*StreamStatus
+	history map[string][]StreamVersion
+	// The latest version number given to each stream, which is kept until the
+	// stream is deleted so that version numbers are never reused.
+	versions map[string]int
+	opLocks  map[string]*streamOpLock
 
-	manager    bundle.NewManagement
-	apiEnabled bool
+	manager      bundle.NewManagement
+	apiEnabled   bool
+	historyLimit int
 
 	lock sync.Mutex
 }
@@ -94,9 +117,13 @@ type Type struct {
 // New creates a new stream manager.Type.
 func New(mgr bundle.NewManagement, opts ...func(*Type)) *Type {
 	t := &Type{
-		streams:    map[string]*StreamStatus{},
-		apiEnabled: true,
-		manager:    mgr,
+		streams:      map[string]*StreamStatus{},
+		history:      map[string][]StreamVersion{},
+		versions:     map[string]int{},
+		opLocks:      map[string]*streamOpLock{},
+		apiEnabled:   true,
+		historyLimit: 10,
+		manager:      mgr,
 	}
 	for _, opt := range opts {
 		opt(t)
@@ -115,16 +142,58 @@ func OptAPIEnabled(b bool) func(*Type) {
 	}
 }
 
+// OptVersionHistoryLimit sets the maximum number of previous configurations
+// kept for each stream in order to support rollbacks. This is 10 by default,
+// and a limit of zero disables version history.
+func OptVersionHistoryLimit(n int) func(*Type) {
+	return func(t *Type) {
+		t.historyLimit = n
+	}
+}
+
 //------------------------------------------------------------------------------
 
 // Errors specifically returned by a stream manager.
 var (
-	ErrStreamExists       = errors.New("stream already exists")
-	ErrStreamDoesNotExist = errors.New("stream does not exist")
+	ErrStreamExists              = errors.New("stream already exists")
+	ErrStreamDoesNotExist        = errors.New("stream does not exist")
+	ErrStreamVersionDoesNotExist = errors.New("stream version does not exist")
 )
 
 //------------------------------------------------------------------------------
 
+type streamOpLock struct {
+	mut  sync.Mutex
+	refs int
+}
+
+// lockStream serialises operations that modify a stream and its version
+// history across multiple steps, such as stopping a stream and then creating a
+// new version of it. The returned func releases the lock.
+func (m *Type) lockStream(id string) func() {
+	m.lock.Lock()
+	l, exists := m.opLocks[id]
+	if !exists {
+		l = &streamOpLock{}
+		m.opLocks[id] = l
+	}
+	l.refs++
+	m.lock.Unlock()
+
+	l.mut.Lock()
+	return func() {
+		l.mut.Unlock()
+
+		m.lock.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(m.opLocks, id)
+		}
+		m.lock.Unlock()
+	}
+}
+
+//------------------------------------------------------------------------------
+
 // ConnectionStatus returns the aggregate connection status of all stream inputs
 // and outputs.
 func (m *Type) ConnectionStatus() (s component.ConnectionStatuses) {
@@ -139,17 +208,26 @@ func (m *Type) ConnectionStatus() (s component.ConnectionStatuses) {
 // Create attempts to construct and run a new stream under a unique ID. If the
 // ID already exists an error is returned.
 func (m *Type) Create(id string, conf stream.Config) error {
+	unlock := m.lockStream(id)
+	defer unlock()
+
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	if m.closed {
-		return component.ErrTypeClosed
-	}
-
 	if _, exists := m.streams[id]; exists {
 		return ErrStreamExists
 	}
 
+	return m.createVersion(id, conf)
+}
+
+// createVersion constructs and runs a stream with the version following the
+// latest version given to the stream.
+func (m *Type) createVersion(id string, conf stream.Config) error {
+	if m.closed {
+		return component.ErrTypeClosed
+	}
+
 	strmFlatMetrics := metrics.NewLocal()
 	sMgr := m.manager.ForStream(id).WithAddedMetrics(strmFlatMetrics)
 
@@ -158,7 +236,8 @@ func (m *Type) Create(id string, conf stream.Config) error {
 	//
 	// This seems a bit wonky but we can't rule out a race condition between
 	// the stream terminating and setClosed and actually initialising a status.
-	wrapper := newStreamStatus(conf, strmFlatMetrics)
+	version := m.versions[id] + 1
+	wrapper := newStreamStatus(conf, version, strmFlatMetrics)
 	strm, err := stream.New(conf, sMgr, stream.OptOnClose(func() {
 		wrapper.setClosed()
 	}))
@@ -168,9 +247,26 @@ func (m *Type) Create(id string, conf stream.Config) error {
 
 	wrapper.setStream(strm)
 	m.streams[id] = wrapper
+	m.versions[id] = version
 	return nil
 }
 
+// addHistory adds the configuration of a stream that has been stopped to its
+// version history, dropping the oldest versions beyond the history limit.
+func (m *Type) addHistory(id string, prev *StreamStatus) {
+	if m.historyLimit <= 0 {
+		return
+	}
+	hist := append(m.history[id], StreamVersion{
+		Version: prev.version,
+		Config:  prev.config,
+	})
+	if len(hist) > m.historyLimit {
+		hist = hist[len(hist)-m.historyLimit:]
+	}
+	m.history[id] = hist
+}
+
 // Read attempts to obtain the status of a managed stream. Returns an error if
 // the stream does not exist.
 func (m *Type) Read(id string) (*StreamStatus, error) {
@@ -190,8 +286,12 @@ func (m *Type) Read(id string) (*StreamStatus, error) {
 }
 
 // Update attempts to stop an existing stream and replace it with a new version
-// of the same stream.
+// of the same stream. The previous configuration of the stream is added to its
+// version history.
 func (m *Type) Update(ctx context.Context, id string, conf stream.Config) error {
+	unlock := m.lockStream(id)
+	defer unlock()
+
 	m.lock.Lock()
 	_, exists := m.streams[id]
 	closed := m.closed
@@ -204,37 +304,136 @@ func (m *Type) Update(ctx context.Context, id string, conf stream.Config) error
 		return ErrStreamDoesNotExist
 	}
 
-	if err := m.Delete(ctx, id); err != nil {
+	prev, err := m.stop(ctx, id)
+	if err != nil {
 		return err
 	}
-	return m.Create(id, conf)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.addHistory(id, prev)
+	return m.createVersion(id, conf)
+}
+
+// History returns the previous configurations of a stream that can be rolled
+// back to, ordered from oldest to newest.
+func (m *Type) History(id string) ([]StreamVersion, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.closed {
+		return nil, component.ErrTypeClosed
+	}
+
+	hist, exists := m.history[id]
+	if _, running := m.streams[id]; !exists && !running {
+		return nil, ErrStreamDoesNotExist
+	}
+	return append([]StreamVersion(nil), hist...), nil
 }
 
-// Delete attempts to stop and remove a stream by its ID. Returns an error if
-// the stream was not found, or if clean shutdown fails in the specified period
-// of time.
+// Rollback attempts to replace a stream with a previous version of its
+// configuration. When version is zero the most recent previous version is
+// used. The target configuration is created as a new version of the stream,
+// and the replaced configuration is added to its version history.
+func (m *Type) Rollback(ctx context.Context, id string, version int) error {
+	unlock := m.lockStream(id)
+	defer unlock()
+
+	m.lock.Lock()
+	closed := m.closed
+	hist := m.history[id]
+	_, exists := m.streams[id]
+	m.lock.Unlock()
+
+	if closed {
+		return component.ErrTypeClosed
+	}
+
+	target := -1
+	for i, v := range hist {
+		if version == 0 || v.Version == version {
+			target = i
+		}
+	}
+	if target == -1 {
+		if !exists {
+			return ErrStreamDoesNotExist
+		}
+		return ErrStreamVersionDoesNotExist
+	}
+
+	// The stream might not exist when a previous update failed to create the
+	// new version, in which case the rollback restores it.
+	var prev *StreamStatus
+	if exists {
+		var err error
+		if prev, err = m.stop(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if prev != nil {
+		m.addHistory(id, prev)
+	}
+	return m.createVersion(id, hist[target].Config)
+}
+
+// Delete attempts to stop and remove a stream by its ID, including its version
+// history. Returns an error if the stream was not found, or if clean shutdown
+// fails in the specified period of time.
+//
+// A stream that is not running because it previously failed to update is
+// still removed.
 func (m *Type) Delete(ctx context.Context, id string) error {
+	unlock := m.lockStream(id)
+	defer unlock()
+
+	_, err := m.stop(ctx, id)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if errors.Is(err, ErrStreamDoesNotExist) {
+		if _, known := m.versions[id]; known {
+			err = nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	delete(m.history, id)
+	delete(m.versions, id)
+	return nil
+}
+
+func (m *Type) stop(ctx context.Context, id string) (*StreamStatus, error) {
 	m.lock.Lock()
 	if m.closed {
 		m.lock.Unlock()
-		return component.ErrTypeClosed
+		return nil, component.ErrTypeClosed
 	}
 
 	wrapper, exists := m.streams[id]
 	m.lock.Unlock()
 	if !exists {
-		return ErrStreamDoesNotExist
+		return nil, ErrStreamDoesNotExist
 	}
 
 	if err := wrapper.strm.Stop(ctx); err != nil {
-		return err
+		return nil, err
 	}
 
 	m.lock.Lock()
 	delete(m.streams, id)
 	m.lock.Unlock()
 
-	return nil
+	return wrapper, nil
 }
 
 //------------------------------------------------------------------------------
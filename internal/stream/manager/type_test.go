@@ -113,3 +113,108 @@ func TestTypeBasicClose(t *testing.T) {
 		t.Errorf("Unexpected error: %v != %v", act, exp)
 	}
 }
+
+func TestTypeRollbackCreateFailure(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	res, err := bmanager.New(bmanager.NewResourceConfig())
+	require.NoError(t, err)
+
+	procConf, err := testutil.ProcessorFromYAML(`mapping: 'root = this'`)
+	require.NoError(t, err)
+	require.NoError(t, res.StoreProcessor(ctx, "foo", procConf))
+
+	resConf, err := testutil.StreamFromYAML(`
+input:
+  generate:
+    mapping: 'root = deleted()'
+pipeline:
+  processors:
+    - resource: foo
+output:
+  drop: {}
+`)
+	require.NoError(t, err)
+
+	mgr := New(res)
+	require.NoError(t, mgr.Create("foo", resConf))
+	require.NoError(t, mgr.Update(ctx, "foo", harmlessConf(t)))
+
+	// Rolling back to a version that can no longer be created stops the
+	// current stream but keeps the history so that it can be retried.
+	require.NoError(t, res.RemoveProcessor(ctx, "foo"))
+	require.Error(t, mgr.Rollback(ctx, "foo", 1))
+
+	_, err = mgr.Read("foo")
+	require.ErrorIs(t, err, ErrStreamDoesNotExist)
+
+	hist, err := mgr.History("foo")
+	require.NoError(t, err)
+	require.Len(t, hist, 2)
+	require.Equal(t, 1, hist[0].Version)
+	require.Equal(t, 2, hist[1].Version)
+
+	require.NoError(t, res.StoreProcessor(ctx, "foo", procConf))
+	require.NoError(t, mgr.Rollback(ctx, "foo", 1))
+
+	info, err := mgr.Read("foo")
+	require.NoError(t, err)
+	require.Equal(t, 3, info.Version())
+	require.Equal(t, resConf, info.Config())
+
+	hist, err = mgr.History("foo")
+	require.NoError(t, err)
+	require.Len(t, hist, 2)
+
+	require.NoError(t, mgr.Stop(ctx))
+}
+
+func TestTypeDeleteAfterUpdateFailure(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	res, err := bmanager.New(bmanager.NewResourceConfig())
+	require.NoError(t, err)
+
+	resConf, err := testutil.StreamFromYAML(`
+input:
+  generate:
+    mapping: 'root = deleted()'
+pipeline:
+  processors:
+    - resource: foo
+output:
+  drop: {}
+`)
+	require.NoError(t, err)
+
+	mgr := New(res)
+	require.NoError(t, mgr.Create("foo", harmlessConf(t)))
+	require.NoError(t, mgr.Update(ctx, "foo", harmlessConf(t)))
+
+	// The processor resource does not exist and so the update fails after the
+	// current stream has been stopped.
+	require.Error(t, mgr.Update(ctx, "foo", resConf))
+
+	_, err = mgr.Read("foo")
+	require.ErrorIs(t, err, ErrStreamDoesNotExist)
+
+	require.NoError(t, mgr.Delete(ctx, "foo"))
+	require.ErrorIs(t, mgr.Delete(ctx, "foo"), ErrStreamDoesNotExist)
+
+	_, err = mgr.History("foo")
+	require.ErrorIs(t, err, ErrStreamDoesNotExist)
+
+	require.NoError(t, mgr.Create("foo", harmlessConf(t)))
+
+	info, err := mgr.Read("foo")
+	require.NoError(t, err)
+	require.Equal(t, 1, info.Version())
+
+	hist, err := mgr.History("foo")
+	require.NoError(t, err)
+	require.Empty(t, hist)
+
+	require.NoError(t, mgr.Stop(ctx))
+}
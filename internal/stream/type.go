@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"runtime/pprof"
 	"sync/atomic"
@@ -196,29 +197,35 @@ func (t *Type) start() (err error) {
 // proxy. This should guarantee that all in-flight and buffered data is resolved
 // before shutting down.
 func (t *Type) StopGracefully(ctx context.Context) (err error) {
+	log := t.manager.Logger()
+
+	log.Debug("Waiting for input to stop consuming")
 	t.inputLayer.TriggerStopConsuming()
 	if err = t.inputLayer.WaitForClose(ctx); err != nil {
-		return
+		return fmt.Errorf("waiting for input to close: %w", err)
 	}
 
 	// If we have a buffer then wait right here. We want to try and allow the
 	// buffer to empty out before prompting the other layers to shut down.
 	if t.bufferLayer != nil {
+		log.Debug("Input closed, waiting for buffer to drain")
 		t.bufferLayer.TriggerStopConsuming()
 		if err = t.bufferLayer.WaitForClose(ctx); err != nil {
-			return
+			return fmt.Errorf("waiting for buffer to close: %w", err)
 		}
 	}
 
 	// After this point we can start closing the remaining components.
 	if t.pipelineLayer != nil {
+		log.Debug("Waiting for pipeline to finish processing in-flight messages")
 		if err = t.pipelineLayer.WaitForClose(ctx); err != nil {
-			return
+			return fmt.Errorf("waiting for pipeline to close: %w", err)
 		}
 	}
 
+	log.Debug("Waiting for output to finish delivering in-flight messages")
 	if err = t.outputLayer.WaitForClose(ctx); err != nil {
-		return
+		return fmt.Errorf("waiting for output to close: %w", err)
 	}
 	return nil
 }
@@ -237,23 +244,23 @@ func (t *Type) StopUnordered(ctx context.Context) (err error) {
 	t.outputLayer.TriggerCloseNow()
 
 	if err = t.inputLayer.WaitForClose(ctx); err != nil {
-		return
+		return fmt.Errorf("waiting for input to close: %w", err)
 	}
 
 	if t.bufferLayer != nil {
 		if err = t.bufferLayer.WaitForClose(ctx); err != nil {
-			return
+			return fmt.Errorf("waiting for buffer to close: %w", err)
 		}
 	}
 
 	if t.pipelineLayer != nil {
 		if err = t.pipelineLayer.WaitForClose(ctx); err != nil {
-			return
+			return fmt.Errorf("waiting for pipeline to close: %w", err)
 		}
 	}
 
 	if err = t.outputLayer.WaitForClose(ctx); err != nil {
-		return
+		return fmt.Errorf("waiting for output to close: %w", err)
 	}
 	return nil
 }
@@ -19,6 +19,18 @@ type RateLimit interface {
 	Closer
 }
 
+// RateLimitFeedbackReceiver is an optional interface that can be implemented by
+// a RateLimit in order to receive the outcome of each request made to the rate
+// limited resource. Components that make requests to a rate limited resource
+// should check whether the rate limit implements this interface and, if so,
+// report the latency of each request and whether it was throttled.
+type RateLimitFeedbackReceiver interface {
+	// Feedback reports the latency of a request made to the rate limited
+	// resource, and whether the resource signalled that requests should be
+	// throttled (e.g. a 429 or 5xx status code).
+	Feedback(ctx context.Context, latency time.Duration, throttled bool)
+}
+
 //------------------------------------------------------------------------------
 
 func newAirGapRateLimit(c RateLimit, stats metrics.Type) ratelimit.V1 {
@@ -40,6 +52,12 @@ func (a *reverseAirGapRateLimit) Access(ctx context.Context) (time.Duration, err
 	return a.r.Access(ctx)
 }
 
+func (a *reverseAirGapRateLimit) Feedback(ctx context.Context, latency time.Duration, throttled bool) {
+	if f, ok := a.r.(ratelimit.FeedbackReceiver); ok {
+		f.Feedback(ctx, latency, throttled)
+	}
+}
+
 func (a *reverseAirGapRateLimit) Close(ctx context.Context) error {
 	return a.r.Close(ctx)
 }
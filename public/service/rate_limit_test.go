@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/redpanda-data/benthos/v4/internal/component/metrics"
 )
@@ -86,3 +87,36 @@ func TestRateLimitReverseAirGapShutdown(t *testing.T) {
 	assert.NoError(t, agrl.Close(context.Background()))
 	assert.True(t, rl.closed)
 }
+
+//------------------------------------------------------------------------------
+
+type feedbackRateLimit struct {
+	closableRateLimit
+	latencies []time.Duration
+	throttled []bool
+}
+
+func (f *feedbackRateLimit) Feedback(ctx context.Context, latency time.Duration, throttled bool) {
+	f.latencies = append(f.latencies, latency)
+	f.throttled = append(f.throttled, throttled)
+}
+
+func TestRateLimitAirGapFeedback(t *testing.T) {
+	ctx := context.Background()
+	rl := &feedbackRateLimit{}
+
+	rrl := RateLimit(newReverseAirGapRateLimit(newAirGapRateLimit(rl, metrics.Noop())))
+
+	f, ok := rrl.(RateLimitFeedbackReceiver)
+	require.True(t, ok)
+
+	f.Feedback(ctx, time.Second, true)
+	f.Feedback(ctx, time.Millisecond, false)
+
+	assert.Equal(t, []time.Duration{time.Second, time.Millisecond}, rl.latencies)
+	assert.Equal(t, []bool{true, false}, rl.throttled)
+
+	// Rate limits without feedback support are unaffected.
+	rrl = newReverseAirGapRateLimit(newAirGapRateLimit(&closableRateLimit{}, metrics.Noop()))
+	rrl.(RateLimitFeedbackReceiver).Feedback(ctx, time.Second, true)
+}
@@ -135,6 +135,11 @@ func (f *wrapperFS) MkdirAll(path string, perm fs.FileMode) error {
 	return f.fallback.MkdirAll(path, perm)
 }
 
+// Rename moves a file from oldpath to newpath.
+func (f *wrapperFS) Rename(oldpath, newpath string) error {
+	return ifs.Rename(f.fallback, oldpath, newpath)
+}
+
 // FS implements a superset of fs.FS and includes goodies that benthos
 // components specifically need.
 type FS struct {
@@ -176,6 +181,13 @@ func (f *FS) MkdirAll(path string, perm fs.FileMode) error {
 	return f.i.MkdirAll(path, perm)
 }
 
+// Rename moves a file from oldpath to newpath, replacing newpath if it already
+// exists. An error is returned if the underlying filesystem does not support
+// renames.
+func (f *FS) Rename(oldpath, newpath string) error {
+	return ifs.Rename(f.i, oldpath, newpath)
+}
+
 // FS returns an fs.FS implementation that provides isolation or customised
 // behaviour for components that access the filesystem. For example, this might
 // be used to tally files being accessed by components for observability
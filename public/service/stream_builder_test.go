@@ -204,6 +204,27 @@ logger:
 	}
 }
 
+func TestStreamBuilderEnvVarRequired(t *testing.T) {
+	b := service.NewStreamBuilder()
+	b.SetEnvVarLookupFunc(func(string) (string, bool) {
+		return "", false
+	})
+
+	// Missing variables are tolerated, but variables marked as required are
+	// not.
+	require.NoError(t, b.AddInputYAML(`
+generate:
+  mapping: 'root = "${BENTHOS_TEST_ONE}"'
+`))
+
+	err := b.AddInputYAML(`
+generate:
+  mapping: 'root = "${BENTHOS_TEST_ONE:?must be set}"'
+`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BENTHOS_TEST_ONE: must be set")
+}
+
 func TestStreamBuilderConsumerFunc(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -148,6 +148,19 @@ input:
 				"required environment variables were not set",
 			},
 		},
+		{
+			name: "required env var missing with lint disabled",
+			config: `
+input:
+  dog:
+    woof: ${WOOF:?woof must be set}`,
+			linter: schema.NewStreamConfigLinter().
+				SetSkipEnvVarCheck(true).
+				SetEnvVarLookupFunc(func(ctx context.Context, s string) (string, bool) {
+					return "", false
+				}),
+			errContains: "WOOF: woof must be set",
+		},
 	}
 
 	for _, test := range tests {